@@ -0,0 +1,77 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// Operations recorded in the audit log.
+const (
+	auditSwitch  = "switch"
+	auditRename  = "rename"
+	auditDelete  = "delete"
+	auditRestore = "restore" // a deleted context restored by --undo
+	auditUnset   = "unset"
+)
+
+// auditEntry is a line of the audit log.
+type auditEntry struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Target    string    `json:"target"`            // context name, the old one for renames
+	NewName   string    `json:"newName,omitempty"` // new name for renames
+}
+
+// audit appends the entry to the audit log at $KUBECTX_AUDIT_LOG, if it's
+// set. Failures only print a warning, as the change is already made.
+func audit(stderr io.Writer, e auditEntry) {
+	path := os.Getenv(env.EnvAuditLog)
+	if path == "" {
+		return
+	}
+	e.Time = time.Now().UTC().Truncate(time.Second)
+	if err := appendAuditEntry(path, e); err != nil {
+		printer.Warning(stderr, "failed to write the audit log: %v", err)
+	}
+}
+
+// appendAuditEntry appends the entry to the file as a line of JSON. It creates
+// the file and missing parent directories.
+func appendAuditEntry(path string, e auditEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
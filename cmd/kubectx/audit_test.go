@@ -0,0 +1,87 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_audit(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "audit-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "logs", "kubectx.jsonl")
+	defer testutil.WithEnvVar(env.EnvAuditLog, path)()
+
+	var stderr bytes.Buffer
+	audit(&stderr, auditEntry{Operation: auditSwitch, Target: "a"})
+	audit(&stderr, auditEntry{Operation: auditRename, Target: "a", NewName: "b"})
+	if stderr.Len() > 0 {
+		t.Fatalf("unexpected warnings: %s", stderr.String())
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []auditEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var e auditEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid line %q: %v", line, err)
+		}
+		if e.Time.IsZero() {
+			t.Errorf("no time in line %q", line)
+		}
+		got = append(got, e)
+	}
+	want := []auditEntry{
+		{Operation: auditSwitch, Target: "a"},
+		{Operation: auditRename, Target: "a", NewName: "b"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(auditEntry{}, "Time")); diff != "" {
+		t.Fatalf("audit log diff=%s", diff)
+	}
+}
+
+func Test_audit_failureWarns(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "audit-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// the log path is a directory, so it can't be written
+	defer testutil.WithEnvVar(env.EnvAuditLog, dir)()
+
+	var stderr bytes.Buffer
+	audit(&stderr, auditEntry{Operation: auditDelete, Target: "a"})
+	if !strings.Contains(stderr.String(), "failed to write the audit log") {
+		t.Fatalf("expected a warning; got=%q", stderr.String())
+	}
+}
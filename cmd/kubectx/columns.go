@@ -0,0 +1,134 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+const (
+	outputColumns = "columns"
+
+	columnGap      = 2 // spaces between columns
+	minColumnWidth = 8 // narrowest a truncated column can get
+)
+
+// fitColumns returns the widths of the columns of the rows to print them
+// within maxWidth characters, or with no limit if maxWidth isn't positive.
+// Columns that don't fit are dropped from the right, and the rightmost
+// column kept is truncated to fit. The first column is always kept in full.
+func fitColumns(rows [][]string, maxWidth int) []int {
+	var widths []int
+	for _, r := range rows {
+		for i, c := range r {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+	if maxWidth <= 0 {
+		return widths
+	}
+	used := 0
+	for i, w := range widths {
+		if i == 0 {
+			used = w
+			continue
+		}
+		left := maxWidth - used - columnGap
+		if left < min(w, minColumnWidth) {
+			return widths[:i]
+		}
+		if w > left {
+			widths[i] = left
+			return widths[:i+1]
+		}
+		used += columnGap + w
+	}
+	return widths
+}
+
+// truncate shortens s to at most n characters, ending it with "…" if it
+// had to be cut.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
+
+// formatRow aligns the cells of the row in columns of the widths. Cells in
+// columns not in widths are dropped. highlight is applied to the first cell,
+// after it's padded, so colors don't break the alignment.
+func formatRow(row []string, widths []int, highlight func(string) string) string {
+	var b strings.Builder
+	for i, w := range widths {
+		c := ""
+		if i < len(row) {
+			c = truncate(row[i], w)
+		}
+		if i == 0 && highlight != nil {
+			b.WriteString(highlight(c))
+		} else {
+			b.WriteString(c)
+		}
+		if i < len(widths)-1 {
+			b.WriteString(strings.Repeat(" ", w-utf8.RuneCountInString(c)+columnGap))
+		}
+	}
+	return b.String()
+}
+
+// printColumns prints the contexts with their namespace and API server in
+// columns fitting in width, highlighting the current context.
+func printColumns(stdout io.Writer, kc *kubeconfig.Kubeconfig, ctxs []string, cur string, noHeaders bool, width int) error {
+	var rows [][]string
+	if !noHeaders {
+		rows = append(rows, []string{"NAME", "NAMESPACE", "SERVER"})
+	}
+	for _, c := range ctxs {
+		ns, err := kc.NamespaceOfContext(c)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read namespace of context \"%s\"", c)
+		}
+		cluster, err := kc.ClusterOfContext(c)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read cluster of context \"%s\"", c)
+		}
+		rows = append(rows, []string{c, ns, kc.ClusterServer(cluster)})
+	}
+
+	widths := fitColumns(rows, width)
+	for i, r := range rows {
+		var highlight func(string) string
+		if r[0] == cur && !noHeaders && i > 0 {
+			highlight = func(s string) string { return printer.ActiveItemColor.Sprint(s) }
+		}
+		if _, err := fmt.Fprintln(stdout, strings.TrimRight(formatRow(r, widths, highlight), " ")); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
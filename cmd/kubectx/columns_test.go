@@ -0,0 +1,62 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_fitColumns(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "NAMESPACE", "SERVER"},
+		{"minikube", "default", "https://192.168.49.2:8443"},
+		{"prod", "kube-system", "https://prod.example.com"},
+	}
+	tests := []struct {
+		name     string
+		maxWidth int
+		want     []int
+	}{
+		{name: "no limit", maxWidth: 0, want: []int{8, 11, 25}},
+		{name: "fits", maxWidth: 80, want: []int{8, 11, 25}},
+		{name: "exactly fits", maxWidth: 8 + 2 + 11 + 2 + 25, want: []int{8, 11, 25}},
+		{name: "truncates last column", maxWidth: 40, want: []int{8, 11, 17}},
+		{name: "drops column too narrow", maxWidth: 28, want: []int{8, 11}},
+		{name: "truncates middle column", maxWidth: 20, want: []int{8, 10}},
+		{name: "single column", maxWidth: 12, want: []int{8}},
+		{name: "first column is kept in full", maxWidth: 4, want: []int{8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, fitColumns(rows, tt.maxWidth)); diff != "" {
+				t.Errorf("fitColumns() diff=%s", diff)
+			}
+		})
+	}
+}
+
+func Test_formatRow(t *testing.T) {
+	widths := []int{8, 11, 10}
+	got := formatRow([]string{"prod", "kube-system", "https://prod.example.com"}, widths, nil)
+	if want := "prod      kube-system  https://p…"; got != want {
+		t.Errorf("got=%q, want=%q", got, want)
+	}
+	got = formatRow([]string{"prod", "default", "x"}, widths[:2], func(s string) string { return "[" + s + "]" })
+	if want := "[prod]      default"; got != want {
+		t.Errorf("got=%q, want=%q", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// completeFlag is the hidden flag for completion scripts to list the context
+// names starting with a prefix.
+const completeFlag = "--complete"
+
+// CompleteOp prints the context names starting with Prefix, one per line,
+// without colors.
+type CompleteOp struct {
+	Prefix string
+}
+
+// completions returns the names starting with prefix, sorted naturally.
+func completions(names []string, prefix string) []string {
+	var out []string
+	for _, n := range names {
+		if strings.HasPrefix(n, prefix) {
+			out = append(out, n)
+		}
+	}
+	natsort.Sort(out)
+	return out
+}
+
+func (op CompleteOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		if cmdutil.IsNotFoundErr(err) {
+			return nil
+		}
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	for _, n := range completions(kc.ContextNames(), op.Prefix) {
+		if _, err := fmt.Fprintln(stdout, n); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_completions(t *testing.T) {
+	names := []string{"prod-2", "staging", "prod-10", "dev", "prod-1"}
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", []string{"dev", "prod-1", "prod-2", "prod-10", "staging"}},
+		{"prod", []string{"prod-1", "prod-2", "prod-10"}},
+		{"prod-1", []string{"prod-1", "prod-10"}},
+		{"x", nil},
+	}
+	for _, tt := range tests {
+		got := completions(names, tt.prefix)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("completions(%q) diff=%s", tt.prefix, diff)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// CompletionsDirOp indicates intention to print the directory the
+// completion scripts should be installed to for the user's shell.
+type CompletionsDirOp struct{}
+
+// completionsDir returns the recommended directory for the completion
+// scripts of the shell, along with a note on how to make the shell load
+// them, if needed.
+func completionsDir(shell, home string, getenv func(string) string) (string, string, error) {
+	dataHome := getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	switch filepath.Base(shell) {
+	case "bash":
+		if v := getenv("BASH_COMPLETION_USER_DIR"); v != "" {
+			return filepath.Join(v, "completions"), "", nil
+		}
+		return filepath.Join(dataHome, "bash-completion", "completions"), "", nil
+	case "zsh":
+		dir := getenv("ZDOTDIR")
+		if dir == "" {
+			dir = home
+		}
+		dir = filepath.Join(dir, ".zsh", "completions")
+		return dir, fmt.Sprintf("add \"fpath=(%s $fpath)\" to your .zshrc before running compinit", dir), nil
+	case "fish":
+		return filepath.Join(configHome, "fish", "completions"), "", nil
+	case ".", "":
+		return "", "", errors.New("cannot detect your shell as SHELL environment variable is not set, " +
+			"install the scripts from the completion/ directory of the kubectx repository to where your shell loads completions from")
+	}
+	return "", "", errors.Errorf("unknown shell \"%s\", install the scripts from the completion/ directory "+
+		"of the kubectx repository to where your shell loads completions from", filepath.Base(shell))
+}
+
+func (CompletionsDirOp) Run(stdout, stderr io.Writer) error {
+	dir, note, err := completionsDir(os.Getenv("SHELL"), cmdutil.HomeDir(), os.Getenv)
+	if err != nil {
+		return err
+	}
+	if note != "" {
+		printer.Warning(stderr, "%s", note)
+	}
+	_, err = fmt.Fprintln(stdout, dir)
+	return errors.Wrap(err, "write error")
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_completionsDir(t *testing.T) {
+	home := filepath.FromSlash("/home/me")
+	tests := []struct {
+		name    string
+		shell   string
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "bash", shell: "/bin/bash",
+			want: filepath.FromSlash("/home/me/.local/share/bash-completion/completions")},
+		{name: "bash with XDG_DATA_HOME", shell: "/bin/bash",
+			env:  map[string]string{"XDG_DATA_HOME": filepath.FromSlash("/data")},
+			want: filepath.FromSlash("/data/bash-completion/completions")},
+		{name: "bash with BASH_COMPLETION_USER_DIR", shell: "/usr/local/bin/bash",
+			env:  map[string]string{"BASH_COMPLETION_USER_DIR": filepath.FromSlash("/bc")},
+			want: filepath.FromSlash("/bc/completions")},
+		{name: "zsh", shell: "/bin/zsh",
+			want: filepath.FromSlash("/home/me/.zsh/completions")},
+		{name: "zsh with ZDOTDIR", shell: "/bin/zsh",
+			env:  map[string]string{"ZDOTDIR": filepath.FromSlash("/home/me/.config/zsh")},
+			want: filepath.FromSlash("/home/me/.config/zsh/.zsh/completions")},
+		{name: "fish", shell: "/usr/bin/fish",
+			want: filepath.FromSlash("/home/me/.config/fish/completions")},
+		{name: "unknown shell", shell: "/bin/tcsh", wantErr: true},
+		{name: "no shell", shell: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			got, _, err := completionsDir(tt.shell, home, getenv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("completionsDir() err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("completionsDir()=%q, want=%q", got, tt.want)
+			}
+		})
+	}
+}
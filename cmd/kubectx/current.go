@@ -24,9 +24,11 @@ import (
 )
 
 // CurrentOp prints the current context
-type CurrentOp struct{}
+type CurrentOp struct {
+	Plain bool // print nothing instead of failing if current-context is unset
+}
 
-func (_op CurrentOp) Run(stdout, _ io.Writer) error {
+func (op CurrentOp) Run(stdout, _ io.Writer) error {
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
 	defer kc.Close()
 	if err := kc.Parse(); err != nil {
@@ -35,6 +37,9 @@ func (_op CurrentOp) Run(stdout, _ io.Writer) error {
 
 	v := kc.GetCurrentContext()
 	if v == "" {
+		if op.Plain {
+			return nil
+		}
 		return errors.New("current-context is not set")
 	}
 	_, err := fmt.Fprintln(stdout, v)
@@ -0,0 +1,73 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestListOp_autoSingle_confirm(t *testing.T) {
+	tests := []struct {
+		name       string
+		answer     string
+		wantErr    bool
+		wantSwitch bool
+	}{
+		{name: "confirmed", answer: "\n", wantSwitch: true},
+		{name: "cancelled", answer: "no\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testutil.TempHome(t)
+			path := testutil.TempKubeconfig(t, dir, testutil.KC().WithCtxs(testutil.Ctx("prod")))
+
+			in := filepath.Join(dir, "stdin")
+			if err := ioutil.WriteFile(in, []byte(tt.answer), 0644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Open(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			origStdin, origCanPrompt := os.Stdin, canPrompt
+			os.Stdin, canPrompt = f, func() bool { return true }
+			defer func() { os.Stdin, canPrompt = origStdin, origCanPrompt }()
+
+			var stdout, stderr bytes.Buffer
+			err = ListOp{AutoSingle: true}.Run(&stdout, &stderr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err=%v; wantErr=%v", err, tt.wantErr)
+			}
+			if !strings.Contains(stderr.String(), "Press Enter to continue") {
+				t.Errorf("not asked to confirm, stderr=%q", stderr.String())
+			}
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Contains(string(b), "current-context: prod"); got != tt.wantSwitch {
+				t.Errorf("switched=%v; want=%v:\n%s", got, tt.wantSwitch, b)
+			}
+		})
+	}
+}
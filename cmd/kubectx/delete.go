@@ -15,7 +15,11 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
 	"io"
+	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -25,52 +29,135 @@ import (
 
 // DeleteOp indicates intention to delete contexts.
 type DeleteOp struct {
-	Contexts []string // NAME or '.' to indicate current-context.
+	Contexts  []string // NAME or '.' to indicate current-context.
+	FromStdin bool     // also delete the contexts named in stdin, one per line
+	Force     bool     // delete the contexts even if they're locked
+}
+
+// parseDeleteArgs parses the arguments following -d.
+func parseDeleteArgs(argv []string) Op {
+	var op DeleteOp
+	for _, v := range argv {
+		switch v {
+		case "--from-stdin":
+			op.FromStdin = true
+		case "-f", "--force":
+			op.Force = true
+		default:
+			op.Contexts = append(op.Contexts, v)
+		}
+	}
+	if len(op.Contexts) == 0 && !op.FromStdin {
+		return UnsupportedOp{Err: fmt.Errorf("'-d' needs arguments")}
+	}
+	return op
+}
+
+// readContextNames reads context names from r, one per line, skipping blank
+// lines.
+func readContextNames(r io.Reader) ([]string, error) {
+	var out []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if v := strings.TrimSpace(s.Text()); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out, s.Err()
 }
 
 // deleteContexts deletes context entries one by one.
 func (op DeleteOp) Run(_, stderr io.Writer) error {
-	for _, ctx := range op.Contexts {
+	ctxs := op.Contexts
+	if op.FromStdin {
+		names, err := readContextNames(os.Stdin)
+		if err != nil {
+			return errors.Wrap(err, "failed to read context names from stdin")
+		}
+		if len(names) == 0 && len(ctxs) == 0 {
+			return errors.New("no context names were given in stdin")
+		}
+		ctxs = append(ctxs, names...)
+	}
+
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+	var locked map[string]bool
+	if !op.Force {
+		locked = lockSet(locks)
+	}
+
+	var undo undoEntry
+	for _, ctx := range ctxs {
 		// TODO inefficiency here. we open/write/close the same file many times.
-		deletedName, wasActiveContext, err := deleteContext(ctx)
+		deletedName, wasActiveContext, deleted, err := deleteContext(ctx, locked)
 		if err != nil {
 			return errors.Wrapf(err, "error deleting context \"%s\"", deletedName)
 		}
+		l, removed := removeLock(locks, deletedName)
+		deleted.Locked = removed
+		undo.Deleted = append(undo.Deleted, deleted)
+		if err := recordUndo(undo); err != nil {
+			return err
+		}
+		if removed {
+			locks = l
+			if err := writeLocks(locks); err != nil {
+				return err
+			}
+		}
 		if wasActiveContext {
 			printer.Warning(stderr, "You deleted the current context. Use \"%s\" to select a new context.",
 				selfName())
 		}
 
 		printer.Success(stderr, `Deleted context %s.`, printer.SuccessColor.Sprint(deletedName))
+		audit(stderr, auditEntry{Operation: auditDelete, Target: deletedName})
+		runDeleteHook(stderr, deletedName)
+	}
+	if op.FromStdin {
+		printer.Success(stderr, "Deleted %d contexts.", len(undo.Deleted))
 	}
 	return nil
 }
 
 // deleteContext deletes a context entry by NAME or current-context
-// indicated by ".".
-func deleteContext(name string) (deleteName string, wasActiveContext bool, err error) {
+// indicated by ".", unless it's in locked. It returns the deleted entry, so
+// it can be restored.
+func deleteContext(name string, locked map[string]bool) (deleteName string, wasActiveContext bool, deleted deletedContext, err error) {
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
 	defer kc.Close()
 	if err := kc.Parse(); err != nil {
-		return deleteName, false, errors.Wrap(err, "kubeconfig error")
+		return deleteName, false, deleted, errors.Wrap(err, "kubeconfig error")
 	}
 
 	cur := kc.GetCurrentContext()
 	// resolve "." to a real name
 	if name == "." {
 		if cur == "" {
-			return deleteName, false, errors.New("can't use '.' as the no active context is set")
+			return deleteName, false, deleted, errors.New("can't use '.' as the no active context is set")
 		}
-		wasActiveContext = true
 		name = cur
 	}
+	wasActiveContext = name == cur
 
 	if !kc.ContextExists(name) {
-		return name, false, errors.New("context does not exist")
+		return name, false, deleted, errors.New("context does not exist")
+	}
+	if locked[name] {
+		return name, false, deleted, errors.Errorf("context is locked (use \"%s --unlock %s\" or \"-d --force\")",
+			selfName(), name)
 	}
 
+	entry, err := kc.ContextEntry(name)
+	if err != nil {
+		return name, false, deleted, errors.Wrap(err, "failed to read context entry")
+	}
+	deleted = deletedContext{Name: name, Entry: string(entry)}
 	if err := kc.DeleteContextEntry(name); err != nil {
-		return name, false, errors.Wrap(err, "failed to modify yaml doc")
+		return name, false, deleted, errors.Wrap(err, "failed to modify yaml doc")
 	}
-	return name, wasActiveContext, errors.Wrap(kc.Save(), "failed to save modified kubeconfig file")
+	return name, wasActiveContext, deleted, errors.Wrap(kc.Save(), "failed to save modified kubeconfig file")
 }
@@ -0,0 +1,71 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// describeFlag is the flag printing the details of a context, also used by
+// fzf to preview the highlighted context.
+const describeFlag = "--describe"
+
+// DescribeOp prints the details of a context.
+type DescribeOp struct {
+	Context string // NAME, or "" for current-context
+}
+
+func (op DescribeOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	name := op.Context
+	if name == "" {
+		name = kc.GetCurrentContext()
+		if name == "" {
+			return errors.New("current-context is not set")
+		}
+	}
+	if !kc.ContextExists(name) {
+		return errors.Errorf("no context exists with the name: \"%s\"", name)
+	}
+
+	ns, err := kc.NamespaceOfContext(name)
+	if err != nil {
+		return errors.Wrap(err, "cannot read namespace of context")
+	}
+	path, err := kc.ContextSource(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to determine the file defining context \"%s\"", name)
+	}
+	note, err := contextNote(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(stdout, "Context:   %s\nNamespace: %s\nFile:      %s\n", name, ns, path)
+	if err == nil && note != "" {
+		_, err = fmt.Fprintf(stdout, "Note:      %s\n", note)
+	}
+	return errors.Wrap(err, "write error")
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// displayTimeout is how long $KUBECTX_DISPLAY_CMD can take for each name.
+const displayTimeout = time.Second
+
+// displayNamer returns the function transforming context names for display
+// with $KUBECTX_DISPLAY_CMD, or nil if it isn't set. Names it fails to
+// transform are displayed as they are, warning only about the first failure.
+func displayNamer(stderr io.Writer) func(string) string {
+	script := os.Getenv(env.EnvDisplayCmd)
+	if script == "" {
+		return nil
+	}
+	cmdline := cmdutil.ShellCommand(script)
+	var warned bool
+	return func(name string) string {
+		s, err := displayName(cmdline, name, displayTimeout)
+		if err != nil && !warned {
+			warned = true
+			printer.Warning(stderr, "failed to transform context name \"%s\" with $%s: %v",
+				name, env.EnvDisplayCmd, err)
+		}
+		return s
+	}
+}
+
+// displayName runs the command with name on its stdin and returns the line
+// it prints, or name if it fails, times out or prints nothing.
+func displayName(cmdline []string, name string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, cmdline[0], cmdline[1:]...)
+	cmd.Stdin = strings.NewReader(name + "\n")
+	// don't wait for the processes it started that keep stdout open
+	cmd.WaitDelay = 100 * time.Millisecond
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return name, errors.Errorf("timed out after %v", timeout)
+	}
+	if err != nil {
+		return name, err
+	}
+	s := strings.TrimSpace(string(out))
+	if s == "" {
+		return name, errors.New("printed nothing")
+	}
+	if strings.ContainsAny(s, "\t\n") {
+		return name, errors.New("printed more than a line")
+	}
+	return s, nil
+}
+
+// displayFZFArgs returns the fzf arguments and environment variables showing
+// the display names in the interactive mode, if $KUBECTX_DISPLAY_CMD is set.
+// fzf then returns the whole line, see fzfChoice.
+func displayFZFArgs() (args, environ []string) {
+	if os.Getenv(env.EnvDisplayCmd) == "" {
+		return nil, nil
+	}
+	return []string{"--delimiter", "\t", "--with-nth", "2.."}, []string{env.EnvDisplayNames + "=1"}
+}
+
+// fzfChoice returns the context name in the line chosen with fzf, which
+// starts with the name followed by a tab if display names are shown.
+func fzfChoice(out string) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(out), "\t")
+	return name
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+func Test_displayName(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	tests := []struct {
+		name    string
+		script  string
+		want    string
+		wantErr bool
+	}{
+		{name: "transformed", script: "sed 's/^gke_.*_//'", want: "prod"},
+		{name: "fails", script: "exit 1", want: "gke_proj_us-east1_prod", wantErr: true},
+		{name: "prints nothing", script: "cat >/dev/null", want: "gke_proj_us-east1_prod", wantErr: true},
+		{name: "more than a line", script: "cat; echo x", want: "gke_proj_us-east1_prod", wantErr: true},
+		{name: "times out", script: "sleep 5", want: "gke_proj_us-east1_prod", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := displayName(cmdutil.ShellCommand(tt.script), "gke_proj_us-east1_prod", 200*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fzfChoice(t *testing.T) {
+	for in, want := range map[string]string{
+		"prod\n":               "prod",
+		"gke_a_b_prod\tprod\n": "gke_a_b_prod",
+	} {
+		if got := fzfChoice(in); got != want {
+			t.Errorf("fzfChoice(%q)=%q, want %q", in, got, want)
+		}
+	}
+}
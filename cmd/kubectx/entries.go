@@ -0,0 +1,116 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// ListUsersOp indicates intention to list the users in kubeconfig.
+type ListUsersOp struct {
+	Output string // output format, "" for plain text or "json"
+}
+
+// ListClustersOp indicates intention to list the clusters in kubeconfig,
+// along with their servers.
+type ListClustersOp struct {
+	Output string // output format, "" for plain text or "json"
+}
+
+type userEntry struct {
+	Name string `json:"name"`
+}
+
+type clusterEntry struct {
+	Name   string `json:"name"`
+	Server string `json:"server,omitempty"`
+}
+
+// parseListEntriesArgs parses the arguments following --list-users or
+// --list-clusters.
+func parseListEntriesArgs(argv []string) Op {
+	var output string
+	switch {
+	case len(argv) == 1:
+	case len(argv) == 3 && (argv[1] == "-o" || argv[1] == "--output"):
+		if argv[2] != outputJSON {
+			return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[2])}
+		}
+		output = argv[2]
+	default:
+		return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+	}
+	if argv[0] == "--list-users" {
+		return ListUsersOp{Output: output}
+	}
+	return ListClustersOp{Output: output}
+}
+
+func (op ListUsersOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	names := kc.UserNames()
+	natsort.Sort(names)
+	if op.Output == outputJSON {
+		users := make([]userEntry, len(names))
+		for i, n := range names {
+			users[i] = userEntry{Name: n}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(users), "write error")
+	}
+	for _, n := range names {
+		fmt.Fprintln(stdout, n)
+	}
+	return nil
+}
+
+func (op ListClustersOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	names := kc.ClusterNames()
+	natsort.Sort(names)
+	clusters := make([]clusterEntry, len(names))
+	for i, n := range names {
+		clusters[i] = clusterEntry{Name: n, Server: kc.ClusterServer(n)}
+	}
+	if op.Output == outputJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(clusters), "write error")
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, c := range clusters {
+		fmt.Fprintf(w, "%s\t%s\n", c.Name, c.Server)
+	}
+	return errors.Wrap(w.Flush(), "write error")
+}
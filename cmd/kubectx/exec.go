@@ -0,0 +1,49 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+// ExecOp indicates intention to run a command with a kubeconfig that only
+// has the context, without switching to it.
+type ExecOp struct {
+	Context string
+	Command []string // the command and its arguments
+}
+
+// parseExecArgs parses the arguments following --exec.
+func parseExecArgs(argv []string) Op {
+	if len(argv) < 3 || argv[1] != "--" {
+		return UnsupportedOp{Err: fmt.Errorf("'--exec' needs a context name, '--' and a command")}
+	}
+	return ExecOp{Context: argv[0], Command: argv[2:]}
+}
+
+func (op ExecOp) Run(stdout, stderr io.Writer) error {
+	ctx, err := resolveSwitchTarget(op.Context, false)
+	if err != nil {
+		return err
+	}
+	b, err := minifiedKubeconfig(ctx)
+	if err != nil {
+		return err
+	}
+	return cmdutil.RunWithKubeconfig(stdout, stderr, b, "kubectx-exec-*.yaml", op.Command)
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// FishAbbrOp prints fish shell abbreviations switching to the contexts in
+// the custom order (set with --move), to be sourced by config.fish.
+type FishAbbrOp struct{}
+
+// fishAbbrName returns the name of the abbreviation for the context, with
+// the characters fish doesn't allow in abbreviation names replaced.
+func fishAbbrName(ctx string) string {
+	return "kx-" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, ctx)
+}
+
+// fishQuote quotes the value for use in fish, where backslashes in single
+// quotes are escapes as well.
+func fishQuote(v string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+// fishAbbrs returns the abbreviation definitions for the contexts invoking
+// self, skipping contexts whose abbreviation name is taken by an earlier one.
+func fishAbbrs(self string, ctxs []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, c := range ctxs {
+		name := fishAbbrName(c)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, fmt.Sprintf("abbr --add %s %s", name, fishQuote(self+" "+fishQuote(c))))
+	}
+	return out
+}
+
+func (FishAbbrOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	path, err := kubectxOrderFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	order, err := readOrder(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read ordering file")
+	}
+
+	var ctxs []string
+	for _, c := range order {
+		if kc.ContextExists(c) {
+			ctxs = append(ctxs, c)
+		}
+	}
+	for _, v := range fishAbbrs(selfName(), ctxs) {
+		if _, err := fmt.Fprintln(stdout, v); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
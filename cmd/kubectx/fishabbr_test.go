@@ -0,0 +1,33 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_fishAbbrs(t *testing.T) {
+	got := fishAbbrs("kubectx", []string{"prod", "gke_p_us/dev", "gke_p_us:dev", "it's"})
+	want := []string{
+		`abbr --add kx-prod 'kubectx \'prod\''`,
+		`abbr --add kx-gke_p_us-dev 'kubectx \'gke_p_us/dev\''`,
+		`abbr --add kx-it-s 'kubectx \'it\\\'s\''`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("fishAbbrs() diff=%s", diff)
+	}
+}
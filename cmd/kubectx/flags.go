@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/ahmetb/kubectx/internal/cmdutil"
@@ -37,7 +38,18 @@ func parseArgs(argv []string) Op {
 		if cmdutil.IsInteractiveMode(os.Stdout) {
 			return InteractiveSwitchOp{SelfCmd: os.Args[0]}
 		}
-		return ListOp{}
+		return ListOp{AutoSingle: cmdutil.IsAutoSingle()}
+	}
+
+	if argv[0] == completeFlag {
+		if len(argv) > 2 {
+			return UnsupportedOp{Err: fmt.Errorf("'%s' takes at most one prefix", completeFlag)}
+		}
+		var op CompleteOp
+		if len(argv) == 2 {
+			op.Prefix = argv[1]
+		}
+		return op
 	}
 
 	if argv[0] == "-d" {
@@ -48,7 +60,144 @@ func parseArgs(argv []string) Op {
 				return UnsupportedOp{Err: fmt.Errorf("'-d' needs arguments")}
 			}
 		}
-		return DeleteOp{Contexts: argv[1:]}
+		return parseDeleteArgs(argv[1:])
+	}
+
+	if argv[0] == "--rename-template" {
+		return parseRenameTemplateArgs(argv[1:])
+	}
+	if argv[0] == "--rename-regex" {
+		return parseRenameRegexArgs(argv[1:])
+	}
+	if argv[0] == "--rename-from-file" {
+		return parseRenameFromFileArgs(argv[1:])
+	}
+	if argv[0] == "--normalize-names" {
+		return parseNormalizeArgs(argv[1:])
+	}
+	if argv[0] == "--select" {
+		return parseSelectArgs(argv[1:])
+	}
+
+	if argv[0] == "--rename" {
+		if len(argv) != 2 || argv[1] != "--interactive" {
+			return UnsupportedOp{Err: fmt.Errorf("'--rename' needs '--interactive' (or use <NEW_NAME>=<NAME>)")}
+		}
+		if !cmdutil.IsInteractiveMode(os.Stdout) {
+			return UnsupportedOp{Err: fmt.Errorf("'--rename --interactive' needs interactive mode (fzf installed and a terminal)")}
+		}
+		return InteractiveRenameOp{SelfCmd: os.Args[0]}
+	}
+
+	if argv[0] == "--health" {
+		return parseHealthArgs(argv[1:])
+	}
+
+	if argv[0] == "--lock" || argv[0] == "--unlock" {
+		if len(argv) != 2 {
+			return UnsupportedOp{Err: fmt.Errorf("'%s' needs a context name", argv[0])}
+		}
+		if argv[0] == "--lock" {
+			return LockOp{Context: argv[1]}
+		}
+		return UnlockOp{Context: argv[1]}
+	}
+
+	if argv[0] == "--move" {
+		return parseMoveArgs(argv[1:])
+	}
+
+	if argv[0] == "--touch" {
+		if len(argv) != 2 {
+			return UnsupportedOp{Err: fmt.Errorf("'--touch' needs a context name")}
+		}
+		return TouchOp{Context: argv[1]}
+	}
+
+	if argv[0] == "--query" {
+		return parseQueryArgs(argv)
+	}
+
+	if argv[0] == "--set-namespace" {
+		if len(argv) != 2 {
+			return UnsupportedOp{Err: fmt.Errorf("'--set-namespace' needs a namespace name")}
+		}
+		return SetNamespaceOp{Namespace: argv[1]}
+	}
+
+	if argv[0] == "--validate-name" {
+		if len(argv) != 2 {
+			return UnsupportedOp{Err: fmt.Errorf("'--validate-name' needs a name")}
+		}
+		return ValidateNameOp{Name: argv[1]}
+	}
+
+	if argv[0] == "--where" {
+		switch len(argv) {
+		case 1:
+			return WhereOp{}
+		case 2:
+			return WhereOp{Context: argv[1]}
+		}
+		return UnsupportedOp{Err: fmt.Errorf("too many arguments")}
+	}
+
+	if argv[0] == describeFlag {
+		switch len(argv) {
+		case 1:
+			return DescribeOp{}
+		case 2:
+			return DescribeOp{Context: argv[1]}
+		}
+		return UnsupportedOp{Err: fmt.Errorf("too many arguments")}
+	}
+
+	if argv[0] == "--list-users" || argv[0] == "--list-clusters" {
+		return parseListEntriesArgs(argv)
+	}
+
+	if argv[0] == "--stats" {
+		return parseStatsArgs(argv[1:])
+	}
+	if argv[0] == "--tree" {
+		return parseTreeArgs(argv[1:])
+	}
+
+	if slices.Contains([]string{"--only-reachable", "--group", "-o", "--json", "--since", "--last-n",
+		"--show-last-used", "--show-source"}, argv[0]) ||
+		(len(argv) > 1 && (argv[0] == "--no-headers" || strings.HasPrefix(argv[0], "--sort="))) {
+		return parseListFlags(argv)
+	}
+
+	if argv[0] == "--exec" {
+		return parseExecArgs(argv[1:])
+	}
+
+	if argv[0] == "--resolve" {
+		return parseResolveArgs(argv[1:])
+	}
+
+	if argv[0] == "--watch-current" {
+		return parseWatchCurrentArgs(argv[1:])
+	}
+
+	if argv[0] == "--require" {
+		return parseRequireArgs(argv[1:])
+	}
+
+	if argv[0] == "--prune-unused" {
+		return parsePruneUnusedArgs(argv[1:])
+	}
+	if argv[0] == "--prune" {
+		return parsePruneArgs(argv[1:])
+	}
+
+	if argv[0] == "--reset" {
+		return parseResetArgs(argv[1:])
+	}
+
+	if argv[0] == "--note" {
+		return parseNoteArgs(argv[1:])
 	}
 
 	if len(argv) == 1 {
@@ -62,18 +211,111 @@ func parseArgs(argv []string) Op {
 		if v == "--current" || v == "-c" {
 			return CurrentOp{}
 		}
+		if v == "--auto-single" {
+			return ListOp{AutoSingle: true}
+		}
+		if v == "--no-headers" {
+			return ListOp{NoHeaders: true}
+		}
+		if v == "--plain-current" {
+			return CurrentOp{Plain: true}
+		}
 		if v == "--unset" || v == "-u" {
 			return UnsetOp{}
 		}
+		if v == "--reset-stats" {
+			return ResetStatsOp{}
+		}
+		if v == "--locks" {
+			return ListLocksOp{}
+		}
+		if v == "--groups" {
+			return ListGroupsOp{}
+		}
+		if v == "--undo" {
+			return UndoOp{}
+		}
+		if v == "--interactive-current" {
+			if !cmdutil.IsInteractiveMode(os.Stdout) {
+				return UnsupportedOp{Err: fmt.Errorf("'--interactive-current' needs interactive mode (fzf installed and a terminal)")}
+			}
+			return InteractiveSwitchOp{SelfCmd: os.Args[0], SelectCurrent: true}
+		}
+		if v == "--peek" {
+			return PeekOp{}
+		}
+		if v == "--print-completions-dir" {
+			return CompletionsDirOp{}
+		}
+		if v == "--print-fish-abbr" {
+			return FishAbbrOp{}
+		}
+		if v == "--graph" {
+			return GraphOp{}
+		}
+		if v == "--which-kubeconfig" {
+			return WhichConfigOp{}
+		}
+		if strings.HasPrefix(v, "--sort=") {
+			return parseSortArg(v)
+		}
 
 		if new, old, ok := parseRenameSyntax(v); ok {
 			return RenameOp{New: new, Old: old}
 		}
 
-		if strings.HasPrefix(v, "-") && v != "-" {
+	}
+	if op, ok := parseForcedRename(argv); ok {
+		return op
+	}
+	return parseSwitchArgs(argv)
+}
+
+// parseSwitchArgs parses the context name to switch to, along with the flags
+// modifying how the switch happens.
+func parseSwitchArgs(argv []string) Op {
+	var op SwitchOp
+	var targets []string
+	for _, v := range argv {
+		switch {
+		case v == "--strict":
+			op.Strict = true
+		case v == "--isolate":
+			op.Isolate = true
+		case v == "--warn-cross-project":
+			op.WarnCrossProject = true
+		case v == "-f" || v == "--force" || v == "-y" || v == "--yes":
+			op.Yes = true
+		case strings.HasPrefix(v, "-") && v != "-":
 			return UnsupportedOp{Err: fmt.Errorf("unsupported option '%s'", v)}
+		default:
+			targets = append(targets, v)
+		}
+	}
+	if len(targets) == 0 {
+		return UnsupportedOp{Err: fmt.Errorf("a context name is needed")}
+	} else if len(targets) > 1 {
+		return UnsupportedOp{Err: fmt.Errorf("too many arguments")}
+	}
+	op.Target = targets[0]
+	return op
+}
+
+// parseQueryArgs parses one or more "--query <TERM>" flags into an
+// interactive switch pre-filtered with the terms.
+func parseQueryArgs(argv []string) Op {
+	var queries []string
+	for i := 0; i < len(argv); i += 2 {
+		if argv[i] != "--query" {
+			return UnsupportedOp{Err: fmt.Errorf("'--query' can't be combined with %q", argv[i])}
 		}
-		return SwitchOp{Target: argv[0]}
+		if i+1 >= len(argv) {
+			return UnsupportedOp{Err: fmt.Errorf("'--query' needs an argument")}
+		}
+		queries = append(queries, argv[i+1])
+	}
+	if !cmdutil.IsInteractiveMode(os.Stdout) {
+		return UnsupportedOp{Err: fmt.Errorf("'--query' needs interactive mode (fzf installed and a terminal)")}
 	}
-	return UnsupportedOp{Err: fmt.Errorf("too many arguments")}
+	return InteractiveSwitchOp{SelfCmd: os.Args[0], Queries: queries}
 }
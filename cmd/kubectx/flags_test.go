@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -45,6 +46,15 @@ func Test_parseArgs_new(t *testing.T) {
 		{name: "current long form",
 			args: []string{"--current"},
 			want: CurrentOp{}},
+		{name: "list or switch to the only context",
+			args: []string{"--auto-single"},
+			want: ListOp{AutoSingle: true}},
+		{name: "list without decoration",
+			args: []string{"--no-headers"},
+			want: ListOp{NoHeaders: true}},
+		{name: "plain current",
+			args: []string{"--plain-current"},
+			want: CurrentOp{Plain: true}},
 		{name: "unset shorthand",
 			args: []string{"-u"},
 			want: UnsetOp{}},
@@ -54,6 +64,30 @@ func Test_parseArgs_new(t *testing.T) {
 		{name: "switch by name",
 			args: []string{"foo"},
 			want: SwitchOp{Target: "foo"}},
+		{name: "switch strictly",
+			args: []string{"--strict", "foo"},
+			want: SwitchOp{Target: "foo", Strict: true}},
+		{name: "switch strictly flag after name",
+			args: []string{"foo", "--strict"},
+			want: SwitchOp{Target: "foo", Strict: true}},
+		{name: "strict without name",
+			args: []string{"--strict"},
+			want: UnsupportedOp{Err: fmt.Errorf("a context name is needed")}},
+		{name: "switch isolated",
+			args: []string{"foo", "--isolate"},
+			want: SwitchOp{Target: "foo", Isolate: true}},
+		{name: "switch warning about cross-project switches",
+			args: []string{"-", "--warn-cross-project"},
+			want: SwitchOp{Target: "-", WarnCrossProject: true}},
+		{name: "switch without confirming",
+			args: []string{"prod", "--yes"},
+			want: SwitchOp{Target: "prod", Yes: true}},
+		{name: "switch forced",
+			args: []string{"-f", "prod"},
+			want: SwitchOp{Target: "prod", Yes: true}},
+		{name: "switch by swap isolated",
+			args: []string{"--isolate", "-"},
+			want: SwitchOp{Target: "-", Isolate: true}},
 		{name: "switch by swap",
 			args: []string{"-"},
 			want: SwitchOp{Target: "-"}},
@@ -62,16 +96,391 @@ func Test_parseArgs_new(t *testing.T) {
 			want: UnsupportedOp{fmt.Errorf("'-d' needs arguments")}},
 		{name: "delete - current context",
 			args: []string{"-d", "."},
-			want: DeleteOp{[]string{"."}}},
+			want: DeleteOp{Contexts: []string{"."}}},
 		{name: "delete - multiple contexts",
 			args: []string{"-d", ".", "a", "b"},
-			want: DeleteOp{[]string{".", "a", "b"}}},
+			want: DeleteOp{Contexts: []string{".", "a", "b"}}},
+		{name: "delete - from stdin",
+			args: []string{"-d", "--from-stdin"},
+			want: DeleteOp{FromStdin: true}},
+		{name: "delete - from stdin and arguments",
+			args: []string{"-d", "a", "--from-stdin"},
+			want: DeleteOp{Contexts: []string{"a"}, FromStdin: true}},
+		{name: "delete - force",
+			args: []string{"-d", "a", "--force"},
+			want: DeleteOp{Contexts: []string{"a"}, Force: true}},
+		{name: "delete - force without contexts",
+			args: []string{"-d", "-f"},
+			want: UnsupportedOp{fmt.Errorf("'-d' needs arguments")}},
+		{name: "lock context",
+			args: []string{"--lock", "a"},
+			want: LockOp{Context: "a"}},
+		{name: "unlock context",
+			args: []string{"--unlock", "a"},
+			want: UnlockOp{Context: "a"}},
+		{name: "lock without context",
+			args: []string{"--lock"},
+			want: UnsupportedOp{fmt.Errorf("'--lock' needs a context name")}},
+		{name: "complete all contexts",
+			args: []string{"--complete"},
+			want: CompleteOp{}},
+		{name: "complete prefix",
+			args: []string{"--complete", "prod"},
+			want: CompleteOp{Prefix: "prod"}},
+		{name: "complete too many prefixes",
+			args: []string{"--complete", "a", "b"},
+			want: UnsupportedOp{fmt.Errorf("'--complete' takes at most one prefix")}},
+		{name: "list locks",
+			args: []string{"--locks"},
+			want: ListLocksOp{}},
 		{name: "rename context",
 			args: []string{"a=b"},
-			want: RenameOp{"a", "b"}},
+			want: RenameOp{New: "a", Old: "b"}},
 		{name: "rename context with old=current",
 			args: []string{"a=."},
-			want: RenameOp{"a", "."}},
+			want: RenameOp{New: "a", Old: "."}},
+		{name: "forced rename",
+			args: []string{"--force", "a=b"},
+			want: RenameOp{New: "a", Old: "b", Force: true}},
+		{name: "forced rename, flag last",
+			args: []string{"a=b", "-f"},
+			want: RenameOp{New: "a", Old: "b", Force: true}},
+		{name: "rename regex",
+			args: []string{"--rename-regex", "^gke_project_", ""},
+			want: RenameRegexOp{Pattern: "^gke_project_", Replacement: ""}},
+		{name: "rename regex dry run",
+			args: []string{"--rename-regex", "--dry-run", "a(.*)", "b$1"},
+			want: RenameRegexOp{Pattern: "a(.*)", Replacement: "b$1", DryRun: true}},
+		{name: "rename regex without replacement",
+			args: []string{"--rename-regex", "a"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--rename-regex' needs a pattern and a replacement")}},
+		{name: "rename from file",
+			args: []string{"--rename-from-file", "plan.txt", "--dry-run"},
+			want: RenameFromFileOp{File: "plan.txt", DryRun: true}},
+		{name: "rename from file without file",
+			args: []string{"--rename-from-file"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--rename-from-file' needs a mapping file")}},
+		{name: "touch context",
+			args: []string{"--touch", "foo"},
+			want: TouchOp{Context: "foo"}},
+		{name: "touch without context",
+			args: []string{"--touch"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--touch' needs a context name")}},
+		{name: "set namespace",
+			args: []string{"--set-namespace", "ns1"},
+			want: SetNamespaceOp{Namespace: "ns1"}},
+		{name: "set namespace without name",
+			args: []string{"--set-namespace"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--set-namespace' needs a namespace name")}},
+		{name: "rename interactively in non-interactive mode",
+			args: []string{"--rename", "--interactive"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--rename --interactive' needs interactive mode (fzf installed and a terminal)")}},
+		{name: "rename without interactive",
+			args: []string{"--rename", "foo"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--rename' needs '--interactive' (or use <NEW_NAME>=<NAME>)")}},
+		{name: "rename template for all contexts",
+			args: []string{"--rename-template", "{{.Cluster}}", "--dry-run"},
+			want: RenameTemplateOp{Template: "{{.Cluster}}", DryRun: true}},
+		{name: "rename template for some contexts",
+			args: []string{"--rename-template", "{{.Cluster}}", "a", "."},
+			want: RenameTemplateOp{Template: "{{.Cluster}}", Contexts: []string{"a", "."}}},
+		{name: "rename template without template",
+			args: []string{"--rename-template"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--rename-template' needs a template")}},
+		{name: "list users",
+			args: []string{"--list-users"},
+			want: ListUsersOp{}},
+		{name: "list clusters in json",
+			args: []string{"--list-clusters", "-o", "json"},
+			want: ListClustersOp{Output: "json"}},
+		{name: "list clusters in unsupported format",
+			args: []string{"--list-clusters", "-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", "yaml")}},
+		{name: "exec",
+			args: []string{"--exec", "foo", "--", "kubectl", "get", "pods", "--no-headers"},
+			want: ExecOp{Context: "foo", Command: []string{"kubectl", "get", "pods", "--no-headers"}}},
+		{name: "exec without command",
+			args: []string{"--exec", "foo", "--"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--exec' needs a context name, '--' and a command")}},
+		{name: "list only reachable contexts",
+			args: []string{"--only-reachable"},
+			want: ListOp{ReachableTimeout: 5 * time.Second, ReachableConcurrency: 8}},
+		{name: "list only reachable contexts with options",
+			args: []string{"--only-reachable", "--timeout", "1s", "--no-headers", "--sort=custom"},
+			want: ListOp{ReachableTimeout: time.Second, ReachableConcurrency: 8, NoHeaders: true, Sort: "custom"}},
+		{name: "list only reachable contexts with concurrency",
+			args: []string{"--only-reachable", "--concurrency", "2"},
+			want: ListOp{ReachableTimeout: 5 * time.Second, ReachableConcurrency: 2}},
+		{name: "list only reachable contexts with invalid concurrency",
+			args: []string{"--only-reachable", "--concurrency", "0"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid concurrency %q", "0")}},
+		{name: "concurrency without only reachable",
+			args: []string{"--group", "dev", "--concurrency", "2"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--concurrency' needs '--only-reachable'")}},
+		{name: "list only reachable contexts with invalid timeout",
+			args: []string{"--only-reachable", "--timeout", "0"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid timeout %q", "0")}},
+		{name: "list group",
+			args: []string{"--group", "dev"},
+			want: ListOp{Group: "dev"}},
+		{name: "list group with options",
+			args: []string{"--group", "dev", "--sort=interactive", "--only-reachable", "--timeout", "1s"},
+			want: ListOp{Group: "dev", Sort: "interactive", ReachableTimeout: time.Second, ReachableConcurrency: 8}},
+		{name: "list only reachable contexts of group",
+			args: []string{"--only-reachable", "--group", "dev"},
+			want: ListOp{Group: "dev", ReachableTimeout: 5 * time.Second, ReachableConcurrency: 8}},
+		{name: "group without name",
+			args: []string{"--group"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--group' needs a group name")}},
+		{name: "group with timeout only",
+			args: []string{"--group", "dev", "--timeout", "1s"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--timeout' needs '--only-reachable'")}},
+		{name: "list with last used times",
+			args: []string{"--show-last-used"},
+			want: ListOp{ShowLastUsed: true}},
+		{name: "list with last used times in json",
+			args: []string{"--show-last-used", "-o", "json"},
+			want: ListOp{ShowLastUsed: true, Output: "json"}},
+		{name: "list in json",
+			args: []string{"-o", "json"},
+			want: ListOp{Output: "json"}},
+		{name: "list in json with all details",
+			args: []string{"--json", "--show-source", "--show-last-used"},
+			want: ListOp{Output: "json", ShowSource: true, ShowLastUsed: true}},
+		{name: "list with sources",
+			args: []string{"--show-source", "--group", "dev"},
+			want: ListOp{ShowSource: true, Group: "dev"}},
+		{name: "list in columns with sources",
+			args: []string{"-o", "columns", "--show-source"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--show-last-used' and '--show-source' can't be combined with '-o columns'")}},
+		{name: "list in columns",
+			args: []string{"-o", "columns"},
+			want: ListOp{Output: "columns"}},
+		{name: "list group in columns",
+			args: []string{"--group", "dev", "-o", "columns", "--no-headers"},
+			want: ListOp{Group: "dev", Output: "columns", NoHeaders: true}},
+		{name: "list in columns without headers",
+			args: []string{"-o", "columns", "--no-headers"},
+			want: ListOp{Output: "columns", NoHeaders: true}},
+		{name: "list without headers in columns",
+			args: []string{"--no-headers", "-o", "columns"},
+			want: ListOp{Output: "columns", NoHeaders: true}},
+		{name: "list as json sorted",
+			args: []string{"-o", "json", "--sort=custom"},
+			want: ListOp{Output: "json", Sort: "custom"}},
+		{name: "list sorted as json",
+			args: []string{"--sort=custom", "-o", "json"},
+			want: ListOp{Output: "json", Sort: "custom"}},
+		{name: "list sorted with last used",
+			args: []string{"--sort=interactive", "--show-last-used"},
+			want: ListOp{Sort: "interactive", ShowLastUsed: true}},
+		{name: "list in unsupported format",
+			args: []string{"-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", "yaml")}},
+		{name: "list contexts used since",
+			args: []string{"--since", "1h"},
+			want: ListOp{Since: time.Hour}},
+		{name: "list last n contexts used since",
+			args: []string{"--since", "90m", "--last-n", "3", "-o", "columns"},
+			want: ListOp{Since: 90 * time.Minute, LastN: 3, Output: "columns"}},
+		{name: "list last n contexts",
+			args: []string{"--last-n", "5"},
+			want: ListOp{LastN: 5}},
+		{name: "since with invalid duration",
+			args: []string{"--since", "1"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid duration %q", "1")}},
+		{name: "last n with invalid number",
+			args: []string{"--last-n", "0"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid number of contexts %q", "0")}},
+		{name: "list groups",
+			args: []string{"--groups"},
+			want: ListGroupsOp{}},
+		{name: "resolve",
+			args: []string{"--resolve", "prod"},
+			want: ResolveOp{Target: "prod"}},
+		{name: "resolve strictly",
+			args: []string{"--resolve", "prod", "--strict"},
+			want: ResolveOp{Target: "prod", Strict: true}},
+		{name: "resolve without name",
+			args: []string{"--resolve", "--strict"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--resolve' needs a context name")}},
+		{name: "watch current context",
+			args: []string{"--watch-current"},
+			want: WatchCurrentOp{}},
+		{name: "watch current context in json",
+			args: []string{"--watch-current", "-o", "json"},
+			want: WatchCurrentOp{Output: "json"}},
+		{name: "watch current context with unsupported format",
+			args: []string{"--watch-current", "-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", []string{"-o", "yaml"})}},
+		{name: "require context",
+			args: []string{"--require", "prod*"},
+			want: RequireOp{Context: "prod*"}},
+		{name: "require context and namespace",
+			args: []string{"--require", "prod", "--require-namespace", "kube-*"},
+			want: RequireOp{Context: "prod", Namespace: "kube-*"}},
+		{name: "require without pattern",
+			args: []string{"--require", "--require-namespace", "default"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--require' needs a context name or pattern")}},
+		{name: "require namespace without pattern",
+			args: []string{"--require", "prod", "--require-namespace"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--require-namespace' needs a namespace pattern")}},
+		{name: "graph",
+			args: []string{"--graph"},
+			want: GraphOp{}},
+		{name: "tree",
+			args: []string{"--tree"},
+			want: TreeOp{}},
+		{name: "tree as json",
+			args: []string{"--tree", "-o", "json"},
+			want: TreeOp{Output: "json"}},
+		{name: "tree unsupported output",
+			args: []string{"--tree", "-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", "yaml")}},
+		{name: "normalize names",
+			args: []string{"--normalize-names"},
+			want: NormalizeOp{}},
+		{name: "normalize names and apply",
+			args: []string{"--normalize-names", "--apply"},
+			want: NormalizeOp{Apply: true}},
+		{name: "normalize names with unsupported option",
+			args: []string{"--normalize-names", "--force"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported option '%s'", "--force")}},
+		{name: "prune unused contexts",
+			args: []string{"--prune-unused", "--older-than", "90d", "--dry-run"},
+			want: PruneUnusedOp{OlderThan: 90 * 24 * time.Hour, DryRun: true}},
+		{name: "prune unused contexts without confirmation",
+			args: []string{"--prune-unused", "-y", "--older-than", "12h"},
+			want: PruneUnusedOp{OlderThan: 12 * time.Hour, Yes: true}},
+		{name: "prune unused contexts including never used ones",
+			args: []string{"--prune-unused", "--older-than", "90d", "--include-never-used"},
+			want: PruneUnusedOp{OlderThan: 90 * 24 * time.Hour, IncludeNeverUsed: true}},
+		{name: "prune unused contexts without duration",
+			args: []string{"--prune-unused"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--prune-unused' needs '--older-than <DURATION>'")}},
+		{name: "prune unused contexts with invalid duration",
+			args: []string{"--prune-unused", "--older-than", "-1d"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid duration %q", "-1d")}},
+		{name: "select",
+			args: []string{"--select", "3"},
+			want: SelectOp{Index: 3}},
+		{name: "select with sort and group",
+			args: []string{"--select", "2", "--sort=custom", "--group", "prod"},
+			want: SelectOp{Index: 2, List: ListOp{Sort: sortCustom, Group: "prod"}}},
+		{name: "select without position",
+			args: []string{"--select"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--select' needs a position")}},
+		{name: "select invalid position",
+			args: []string{"--select", "0"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid position %q", "0")}},
+		{name: "select with output",
+			args: []string{"--select", "1", "-o", "json"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--select' only combines with the flags choosing and ordering the contexts")}},
+		{name: "prune",
+			args: []string{"--prune"},
+			want: PruneOp{}},
+		{name: "prune dry run without confirmation",
+			args: []string{"--prune", "--dry-run", "-y"},
+			want: PruneOp{DryRun: true, Yes: true}},
+		{name: "peek previous context",
+			args: []string{"--peek"},
+			want: PeekOp{}},
+		{name: "list in custom order",
+			args: []string{"--sort=custom"},
+			want: ListOp{Sort: "custom"}},
+		{name: "list in unsupported order",
+			args: []string{"--sort=foo"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported sort order %q", "foo")}},
+		{name: "move",
+			args: []string{"--move", "foo", "2"},
+			want: MoveOp{Context: "foo", Position: 2}},
+		{name: "move without position",
+			args: []string{"--move", "foo"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--move' needs a context name and a position")}},
+		{name: "move to invalid position",
+			args: []string{"--move", "foo", "0"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid position %q, must be a number starting from 1", "0")}},
+		{name: "health",
+			args: []string{"--health"},
+			want: HealthOp{Timeout: 5 * time.Second, Concurrency: 8}},
+		{name: "health with options",
+			args: []string{"--health", "--timeout", "1s", "--concurrency", "2", "-o", "json"},
+			want: HealthOp{Timeout: time.Second, Concurrency: 2, Output: "json"}},
+		{name: "health with invalid timeout",
+			args: []string{"--health", "--timeout", "soon"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid timeout %q", "soon")}},
+		{name: "health with missing concurrency",
+			args: []string{"--health", "--concurrency"},
+			want: UnsupportedOp{Err: fmt.Errorf("'%s' needs an argument", "--concurrency")}},
+		{name: "validate name",
+			args: []string{"--validate-name", "foo"},
+			want: ValidateNameOp{Name: "foo"}},
+		{name: "validate name without name",
+			args: []string{"--validate-name"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--validate-name' needs a name")}},
+		{name: "print completions dir",
+			args: []string{"--print-completions-dir"},
+			want: CompletionsDirOp{}},
+		{name: "undo",
+			args: []string{"--undo"},
+			want: UndoOp{}},
+		{name: "which kubeconfig",
+			args: []string{"--which-kubeconfig"},
+			want: WhichConfigOp{}},
+		{name: "where current context",
+			args: []string{"--where"},
+			want: WhereOp{}},
+		{name: "where context",
+			args: []string{"--where", "foo"},
+			want: WhereOp{Context: "foo"}},
+		{name: "where too many args",
+			args: []string{"--where", "foo", "bar"},
+			want: UnsupportedOp{Err: fmt.Errorf("too many arguments")}},
+		{name: "describe current context",
+			args: []string{"--describe"},
+			want: DescribeOp{}},
+		{name: "describe context",
+			args: []string{"--describe", "foo"},
+			want: DescribeOp{Context: "foo"}},
+		{name: "stats",
+			args: []string{"--stats"},
+			want: StatsOp{}},
+		{name: "stats as json",
+			args: []string{"--stats", "-o", "json"},
+			want: StatsOp{Output: "json"}},
+		{name: "stats in unsupported format",
+			args: []string{"--stats", "-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", "yaml")}},
+		{name: "reset stats",
+			args: []string{"--reset-stats"},
+			want: ResetStatsOp{}},
+		{name: "reset",
+			args: []string{"--reset"},
+			want: ResetOp{}},
+		{name: "reset without confirmation",
+			args: []string{"--reset", "-y"},
+			want: ResetOp{Yes: true}},
+		{name: "reset with unsupported argument",
+			args: []string{"--reset", "foo"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", []string{"foo"})}},
+		{name: "set note",
+			args: []string{"--note", "prod", "prod cluster, be careful"},
+			want: NoteOp{Context: "prod", Note: "prod cluster, be careful"}},
+		{name: "clear note",
+			args: []string{"--note", "prod"},
+			want: NoteOp{Context: "prod"}},
+		{name: "note without context",
+			args: []string{"--note"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--note' needs a context name and an optional note")}},
+		{name: "query in non-interactive mode",
+			args: []string{"--query", "foo"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--query' needs interactive mode (fzf installed and a terminal)")}},
+		{name: "query without term",
+			args: []string{"--query"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--query' needs an argument")}},
+		{name: "query with positional args",
+			args: []string{"--query", "foo", "bar"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--query' can't be combined with %q", "bar")}},
 		{name: "unrecognized flag",
 			args: []string{"-x"},
 			want: UnsupportedOp{Err: fmt.Errorf("unsupported option '-x'")}},
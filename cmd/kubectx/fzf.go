@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -32,14 +33,23 @@ import (
 
 type InteractiveSwitchOp struct {
 	SelfCmd string
+	Queries []string // initial search terms for fzf
+	Group   string   // choose only from the contexts in the group, if set
+
+	SelectCurrent bool // start with the current context highlighted
 }
 
 type InteractiveDeleteOp struct {
 	SelfCmd string
 }
 
+type InteractiveRenameOp struct {
+	SelfCmd string
+}
+
 func (op InteractiveSwitchOp) Run(_, stderr io.Writer) error {
-	// parse kubeconfig just to see if it can be loaded
+	// parse kubeconfig to see if it can be loaded, and to find the current
+	// context to highlight
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
 	if err := kc.Parse(); err != nil {
 		if cmdutil.IsNotFoundErr(err) {
@@ -48,34 +58,104 @@ func (op InteractiveSwitchOp) Run(_, stderr io.Writer) error {
 		}
 		return errors.Wrap(err, "kubeconfig error")
 	}
+	var pos []string
+	if (op.SelectCurrent || cmdutil.IsInteractiveCurrent()) && len(op.Queries) == 0 {
+		var err error
+		if pos, err = op.currentPositionArgs(kc); err != nil {
+			kc.Close()
+			return err
+		}
+	}
 	kc.Close()
 
-	cmd := exec.Command("fzf", "--ansi", "--no-preview")
+	displayArgs, displayEnv := displayFZFArgs()
+	args := []string{"--ansi", "--no-preview"}
+	if notesFile, err := kubectxNotesFile(); err == nil {
+		// preview the notes only if there are some
+		if notes, err := readNotes(notesFile); err == nil && len(notes) > 0 {
+			field := "{}"
+			if displayArgs != nil {
+				field = "{1}" // the context name before the display name
+			}
+			args = []string{"--ansi", "--preview", fmt.Sprintf("%s %s %s", op.SelfCmd, describeFlag, field)}
+		}
+	}
+	args = append(args, displayArgs...)
+	if len(op.Queries) > 0 {
+		args = append(args, "--query", strings.Join(op.Queries, " "))
+	}
+	args = append(args, pos...)
+	cmd := exec.Command("fzf", args...)
 	var out bytes.Buffer
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = stderr
 	cmd.Stdout = &out
 
+	listCmd := fmt.Sprintf("%s --sort=%s", op.SelfCmd, sortInteractive)
+	if op.Group != "" {
+		listCmd = fmt.Sprintf("%s --group %s --sort=%s", op.SelfCmd, cmdutil.ShellQuote(op.Group), sortInteractive)
+	}
 	cmd.Env = append(os.Environ(),
-		fmt.Sprintf("FZF_DEFAULT_COMMAND=%s", op.SelfCmd),
+		"FZF_DEFAULT_COMMAND="+listCmd,
 		fmt.Sprintf("%s=1", env.EnvForceColor))
+	cmd.Env = append(cmd.Env, displayEnv...)
 	if err := cmd.Run(); err != nil {
 		if _, ok := err.(*exec.ExitError); !ok {
 			return err
 		}
 	}
-	choice := strings.TrimSpace(out.String())
+	choice := fzfChoice(out.String())
 	if choice == "" {
 		return errors.New("you did not choose any of the options")
 	}
+	if err := confirmSwitch(stderr, choice, false); err != nil {
+		return errors.Wrap(err, "failed to switch context")
+	}
+	var oldCtx string
+	warn := cmdutil.IsCrossProjectWarning()
+	if warn {
+		oldCtx = currentContextName()
+	}
 	name, err := switchContext(choice)
 	if err != nil {
 		return errors.Wrap(err, "failed to switch context")
 	}
+	audit(stderr, auditEntry{Operation: auditSwitch, Target: name})
 	printer.Success(stderr, "Switched to context \"%s\".", printer.SuccessColor.Sprint(name))
+	if warn {
+		warnCrossProject(stderr, oldCtx, name)
+	}
 	return nil
 }
 
+// currentPositionArgs returns the fzf arguments highlighting the current
+// context when the list is loaded, by finding its line in the list the same
+// way as the listing command of the interactive mode.
+func (op InteractiveSwitchOp) currentPositionArgs(kc *kubeconfig.Kubeconfig) ([]string, error) {
+	ctxs := kc.ContextNames()
+	if err := sortInteractively(ctxs); err != nil {
+		return nil, err
+	}
+	if op.Group != "" {
+		patterns, err := groupPatterns(op.Group)
+		if err != nil {
+			return nil, err
+		}
+		ctxs = filterGroup(ctxs, patterns)
+	}
+	return positionArgs(ctxs, kc.GetCurrentContext()), nil
+}
+
+// positionArgs returns the fzf arguments moving the cursor to the line of
+// name in the list when it's loaded, or nil if it isn't listed.
+func positionArgs(names []string, name string) []string {
+	i := slices.Index(names, name)
+	if i < 0 {
+		return nil
+	}
+	return []string{"--bind", fmt.Sprintf("load:pos(%d)", i+1)}
+}
+
 func (op InteractiveDeleteOp) Run(_, stderr io.Writer) error {
 	// parse kubeconfig just to see if it can be loaded
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
@@ -92,30 +172,42 @@ func (op InteractiveDeleteOp) Run(_, stderr io.Writer) error {
 		return errors.New("no contexts found in config")
 	}
 
-	cmd := exec.Command("fzf", "--ansi", "--no-preview")
+	displayArgs, displayEnv := displayFZFArgs()
+	cmd := exec.Command("fzf", append([]string{"--ansi", "--no-preview"}, displayArgs...)...)
 	var out bytes.Buffer
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = stderr
 	cmd.Stdout = &out
 
 	cmd.Env = append(os.Environ(),
-		fmt.Sprintf("FZF_DEFAULT_COMMAND=%s", op.SelfCmd),
+		fmt.Sprintf("FZF_DEFAULT_COMMAND=%s --sort=%s", op.SelfCmd, sortCustom),
 		fmt.Sprintf("%s=1", env.EnvForceColor))
+	cmd.Env = append(cmd.Env, displayEnv...)
 	if err := cmd.Run(); err != nil {
 		if _, ok := err.(*exec.ExitError); !ok {
 			return err
 		}
 	}
 
-	choice := strings.TrimSpace(out.String())
+	choice := fzfChoice(out.String())
 	if choice == "" {
 		return errors.New("you did not choose any of the options")
 	}
 
-	name, wasActiveContext, err := deleteContext(choice)
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+	name, wasActiveContext, deleted, err := deleteContext(choice, lockSet(locks))
 	if err != nil {
 		return errors.Wrap(err, "failed to delete context")
 	}
+	if err := recordUndo(undoEntry{Deleted: []deletedContext{deleted}}); err != nil {
+		return err
+	}
+
+	audit(stderr, auditEntry{Operation: auditDelete, Target: name})
+	runDeleteHook(stderr, name)
 
 	if wasActiveContext {
 		printer.Warning(stderr, "You deleted the current context. Use \"%s\" to select a new context.",
@@ -126,3 +218,52 @@ func (op InteractiveDeleteOp) Run(_, stderr io.Writer) error {
 
 	return nil
 }
+
+func (op InteractiveRenameOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	if err := kc.Parse(); err != nil {
+		if cmdutil.IsNotFoundErr(err) {
+			printer.Warning(stderr, "kubeconfig file not found")
+			return nil
+		}
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	kc.Close()
+
+	names := kc.ContextNames()
+	if len(names) == 0 {
+		return errors.New("no contexts found in config")
+	}
+
+	displayArgs, displayEnv := displayFZFArgs()
+	cmd := exec.Command("fzf", append([]string{"--ansi", "--no-preview"}, displayArgs...)...)
+	var out bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = stderr
+	cmd.Stdout = &out
+
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("FZF_DEFAULT_COMMAND=%s --sort=%s", op.SelfCmd, sortCustom),
+		fmt.Sprintf("%s=1", env.EnvForceColor))
+	cmd.Env = append(cmd.Env, displayEnv...)
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return err
+		}
+	}
+
+	choice := fzfChoice(out.String())
+	if choice == "" {
+		return errors.New("you did not choose any of the options")
+	}
+
+	new, err := promptNewName(os.Stdin, stderr, names, choice)
+	if err != nil {
+		return err
+	}
+	if new == "" {
+		printer.Warning(stderr, "rename cancelled")
+		return nil
+	}
+	return RenameOp{New: new, Old: choice}.Run(nil, stderr)
+}
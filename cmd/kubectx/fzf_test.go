@@ -0,0 +1,67 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_positionArgs(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	if diff := cmp.Diff([]string{"--bind", "load:pos(2)"}, positionArgs(names, "b")); diff != "" {
+		t.Fatalf("diff=%s", diff)
+	}
+	if v := positionArgs(names, "x"); v != nil {
+		t.Fatalf("expected no arguments for a context not listed; got=%v", v)
+	}
+}
+
+func TestInteractiveDeleteOp_hook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	dir := testutil.TempHome(t)
+	testutil.TempKubeconfig(t, dir, testutil.KC().WithCurrentCtx("a").WithCtxs(testutil.Ctx("a"), testutil.Ctx("b")))
+
+	// a stand-in for fzf choosing "b"
+	bin := filepath.Join(dir, "bin")
+	if err := os.Mkdir(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(bin, "fzf"), []byte("#!/bin/sh\necho b\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer testutil.WithEnvVar("PATH", bin+string(filepath.ListSeparator)+os.Getenv("PATH"))()
+	defer testutil.WithEnvVar(env.EnvPostDeleteHook, "echo deleted")()
+
+	var stderr bytes.Buffer
+	if err := (InteractiveDeleteOp{SelfCmd: "kubectx"}).Run(ioutil.Discard, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if out := stderr.String(); !strings.Contains(out, "deleted b\n") {
+		t.Fatalf("stderr=%q; want the delete hook to run for \"b\"", out)
+	}
+}
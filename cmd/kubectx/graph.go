@@ -0,0 +1,134 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// GraphOp indicates intention to print the contexts, and the clusters and
+// users they refer to, as a Graphviz DOT graph.
+type GraphOp struct{}
+
+// graphContext is a context node of the graph, with the names of the cluster
+// and user it refers to ("" if none).
+type graphContext struct {
+	Name    string
+	Cluster string
+	User    string
+}
+
+// kubeconfigGraph is the relationships between the entries of the kubeconfig.
+type kubeconfigGraph struct {
+	Current  string
+	Contexts []graphContext
+	Clusters []string // defined clusters
+	Users    []string // defined users
+
+	OrphanClusters []string // defined clusters no context refers to
+	OrphanUsers    []string // defined users no context refers to
+}
+
+// dotQuote quotes s as a DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// writeDOT prints the graph in DOT format. Clusters and users that no context
+// refers to are drawn dashed, and the ones referred to but not defined are
+// drawn red.
+func writeDOT(w io.Writer, g kubeconfigGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph kubeconfig {\n  rankdir=LR;\n")
+	for _, c := range g.Contexts {
+		attrs := "shape=ellipse"
+		if c.Name == g.Current {
+			attrs += ", style=bold"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, %s];\n", dotQuote("context/"+c.Name), dotQuote(c.Name), attrs)
+	}
+
+	clusters, users := g.Clusters, g.Users
+	for _, c := range g.Contexts {
+		if c.Cluster != "" && !slices.Contains(clusters, c.Cluster) {
+			clusters = append(clusters, c.Cluster)
+		}
+		if c.User != "" && !slices.Contains(users, c.User) {
+			users = append(users, c.User)
+		}
+	}
+	node := func(kind, shape, name string, defined, orphans []string) {
+		attrs := "shape=" + shape
+		switch {
+		case !slices.Contains(defined, name):
+			attrs += `, color=red, tooltip="not defined"`
+		case slices.Contains(orphans, name):
+			attrs += `, style=dashed, tooltip="not used by any context"`
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, %s];\n", dotQuote(kind+"/"+name), dotQuote(name), attrs)
+	}
+	for _, n := range clusters {
+		node("cluster", "box", n, g.Clusters, g.OrphanClusters)
+	}
+	for _, n := range users {
+		node("user", "note", n, g.Users, g.OrphanUsers)
+	}
+
+	for _, c := range g.Contexts {
+		if c.Cluster != "" {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote("context/"+c.Name), dotQuote("cluster/"+c.Cluster))
+		}
+		if c.User != "" {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote("context/"+c.Name), dotQuote("user/"+c.User))
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return errors.Wrap(err, "write error")
+}
+
+func (GraphOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	g := kubeconfigGraph{
+		Current:  kc.GetCurrentContext(),
+		Clusters: kc.ClusterNames(),
+		Users:    kc.UserNames(),
+	}
+	g.OrphanUsers, g.OrphanClusters = kc.Orphans()
+	for _, name := range kc.ContextNames() {
+		cluster, err := kc.ClusterOfContext(name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read cluster of context \"%s\"", name)
+		}
+		user, err := kc.UserOfContext(name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read user of context \"%s\"", name)
+		}
+		g.Contexts = append(g.Contexts, graphContext{Name: name, Cluster: cluster, User: user})
+	}
+	return writeDOT(stdout, g)
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_writeDOT(t *testing.T) {
+	g := kubeconfigGraph{
+		Current: "a",
+		Contexts: []graphContext{
+			{Name: "a", Cluster: "c1", User: "u1"},
+			{Name: `b"`, Cluster: "c2"},
+		},
+		Clusters:       []string{"c1", "c3"},
+		Users:          []string{"u1"},
+		OrphanClusters: []string{"c3"},
+	}
+	want := `digraph kubeconfig {
+  rankdir=LR;
+  "context/a" [label="a", shape=ellipse, style=bold];
+  "context/b\"" [label="b\"", shape=ellipse];
+  "cluster/c1" [label="c1", shape=box];
+  "cluster/c3" [label="c3", shape=box, style=dashed, tooltip="not used by any context"];
+  "cluster/c2" [label="c2", shape=box, color=red, tooltip="not defined"];
+  "user/u1" [label="u1", shape=note];
+  "context/a" -> "cluster/c1";
+  "context/a" -> "user/u1";
+  "context/b\"" -> "cluster/c2";
+}
+`
+	var out bytes.Buffer
+	if err := writeDOT(&out, g); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Fatalf("diff=%s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+// ListGroupsOp prints the names of the context groups.
+type ListGroupsOp struct{}
+
+// kubectxGroupsFile returns the path of the groups file. Unlike the state
+// files, it's written by the user, so it's never moved or removed.
+func kubectxGroupsFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kubectx-groups"), nil
+}
+
+// readGroups returns the members (context names or glob patterns) of the
+// groups defined in the YAML file by name, or nil if the file doesn't exist.
+func readGroups(path string) (map[string][]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var v map[string][]string
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse groups file %s", path)
+	}
+	return v, nil
+}
+
+// groupPatterns returns the members of the named group in the groups file.
+func groupPatterns(group string) ([]string, error) {
+	path, err := kubectxGroupsFile()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine groups file")
+	}
+	groups, err := readGroups(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read groups file")
+	}
+	patterns, ok := groups[group]
+	if !ok {
+		return nil, errors.Errorf("no group named \"%s\" in %s", group, path)
+	}
+	return patterns, nil
+}
+
+// filterGroup returns the names matching any of the patterns, keeping their
+// order.
+func filterGroup(names, patterns []string) []string {
+	var out []string
+	for _, n := range names {
+		for _, p := range patterns {
+			if cmdutil.MatchGlob(p, n) {
+				out = append(out, n)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (_ ListGroupsOp) Run(stdout, _ io.Writer) error {
+	path, err := kubectxGroupsFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine groups file")
+	}
+	groups, err := readGroups(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read groups file")
+	}
+	names := make([]string, 0, len(groups))
+	for g := range groups {
+		names = append(names, g)
+	}
+	natsort.Sort(names)
+	for _, g := range names {
+		if _, err := fmt.Fprintln(stdout, g); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
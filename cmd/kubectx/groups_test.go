@@ -0,0 +1,71 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_readGroups(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "groups-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "groups")
+
+	v, err := readGroups(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil {
+		t.Fatalf("expected nil groups; got=%v", v)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("dev: [a, 'gke_*_dev']\nprod:\n- b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	v, err = readGroups(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{"dev": {"a", "gke_*_dev"}, "prod": {"b"}}
+	if diff := cmp.Diff(expected, v); diff != "" {
+		t.Fatalf("diff=%s", diff)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("dev: a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readGroups(path); err == nil {
+		t.Fatal("expected error for invalid groups file")
+	}
+}
+
+func Test_filterGroup(t *testing.T) {
+	names := []string{"gke_p_us_dev", "a", "b", "gke_p_us_prod"}
+	got := filterGroup(names, []string{"a", "gke_*_dev", "missing"})
+	if diff := cmp.Diff([]string{"gke_p_us_dev", "a"}, got); diff != "" {
+		t.Fatalf("diff=%s", diff)
+	}
+	if got := filterGroup(names, nil); got != nil {
+		t.Fatalf("expected no matches; got=%v", got)
+	}
+}
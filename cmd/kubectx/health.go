@@ -0,0 +1,163 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeclient"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+const (
+	outputJSON = "json"
+
+	healthOK      = "OK"
+	healthFail    = "FAIL"
+	healthTimeout = "TIMEOUT"
+)
+
+// HealthOp indicates intention to probe the API server of every context.
+type HealthOp struct {
+	Timeout     time.Duration // timeout of each probe
+	Concurrency int           // number of contexts probed at the same time
+	Output      string        // output format, "" for plain text or "json"
+}
+
+// contextHealth is the result of probing the API server of a context.
+type contextHealth struct {
+	Context string `json:"context"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// parseHealthArgs parses the arguments following --health.
+func parseHealthArgs(argv []string) Op {
+	timeout, concurrency, err := cmdutil.HealthDefaults()
+	if err != nil {
+		return UnsupportedOp{Err: err}
+	}
+	op := HealthOp{Timeout: timeout, Concurrency: concurrency}
+	for i := 0; i < len(argv); i++ {
+		v := argv[i]
+		if v != "--timeout" && v != "--concurrency" && v != "-o" && v != "--output" {
+			return UnsupportedOp{Err: fmt.Errorf("unsupported option '%s'", v)}
+		}
+		if i+1 >= len(argv) {
+			return UnsupportedOp{Err: fmt.Errorf("'%s' needs an argument", v)}
+		}
+		i++
+		switch v {
+		case "--timeout":
+			d, err := time.ParseDuration(argv[i])
+			if err != nil || d <= 0 {
+				return UnsupportedOp{Err: fmt.Errorf("invalid timeout %q", argv[i])}
+			}
+			op.Timeout = d
+		case "--concurrency":
+			n, err := strconv.Atoi(argv[i])
+			if err != nil || n < 1 {
+				return UnsupportedOp{Err: fmt.Errorf("invalid concurrency %q", argv[i])}
+			}
+			op.Concurrency = n
+		default:
+			if argv[i] != outputJSON {
+				return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[i])}
+			}
+			op.Output = argv[i]
+		}
+	}
+	return op
+}
+
+// probeFunc checks if the API server of a context is reachable.
+type probeFunc func(ctx context.Context, name string) error
+
+// probeAll runs the probe for each context, at most concurrency at a time,
+// and returns the results in the order of names.
+func probeAll(names []string, concurrency int, timeout time.Duration, probe probeFunc) []contextHealth {
+	out := make([]contextHealth, len(names))
+	cmdutil.ForEachWithTimeout(len(names), concurrency, timeout, func(ctx context.Context, i int) {
+		out[i] = contextHealth{Context: names[i], Status: healthOK}
+		if err := probe(ctx, names[i]); err != nil {
+			out[i].Status = healthFail
+			if isTimeout(ctx, err) {
+				out[i].Status = healthTimeout
+			}
+			out[i].Error = err.Error()
+		}
+	})
+	return out
+}
+
+// isTimeout determines if the probe failed because it took too long.
+func isTimeout(ctx context.Context, err error) bool {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// kubeconfigProbe returns a probe querying the version endpoint of the API
+// servers of the contexts in the kubeconfig.
+func kubeconfigProbe(kc *kubeconfig.Kubeconfig) probeFunc {
+	// serialize client initialization, which reads the kubeconfig
+	var mu sync.Mutex
+	return func(ctx context.Context, name string) error {
+		mu.Lock()
+		clientset, err := kubeclient.NewClientSet(kc, name)
+		mu.Unlock()
+		if err != nil {
+			return errors.Wrap(err, "failed to initialize k8s REST client")
+		}
+		return clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+	}
+}
+
+func (op HealthOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	ctxs := kc.ContextNames()
+	natsort.Sort(ctxs)
+	results := probeAll(ctxs, op.Concurrency, op.Timeout, kubeconfigProbe(kc))
+
+	if op.Output == outputJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(results), "write error")
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Context, r.Status, r.Error)
+	}
+	return errors.Wrap(w.Flush(), "write error")
+}
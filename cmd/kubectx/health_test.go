@@ -0,0 +1,82 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_probeAll(t *testing.T) {
+	probe := func(ctx context.Context, name string) error {
+		switch name {
+		case "down":
+			return errors.New("connection refused")
+		case "slow":
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	got := probeAll([]string{"up", "down", "slow"}, 2, 10*time.Millisecond, probe)
+	want := []contextHealth{
+		{Context: "up", Status: healthOK},
+		{Context: "down", Status: healthFail, Error: "connection refused"},
+		{Context: "slow", Status: healthTimeout, Error: context.DeadlineExceeded.Error()},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("probeAll() diff=%s", diff)
+	}
+}
+
+func Test_probeAll_concurrency(t *testing.T) {
+	var cur, max int32
+	probe := func(context.Context, string) error {
+		n := atomic.AddInt32(&cur, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&cur, -1)
+		return nil
+	}
+
+	probeAll([]string{"a", "b", "c", "d", "e"}, 2, time.Second, probe)
+	if max > 2 {
+		t.Fatalf("%d probes ran at the same time, expected at most 2", max)
+	}
+}
+
+func Test_reachable(t *testing.T) {
+	probe := func(ctx context.Context, name string) error {
+		if name == "down" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	got := reachable([]string{"b", "down", "a"}, time.Second, 2, probe)
+	if diff := cmp.Diff([]string{"b", "a"}, got); diff != "" {
+		t.Fatalf("reachable() diff=%s", diff)
+	}
+}
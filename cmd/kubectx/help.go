@@ -34,15 +34,135 @@ func (_ HelpOp) Run(stdout, _ io.Writer) error {
 func printUsage(out io.Writer) error {
 	help := `USAGE:
   %PROG%                       : list the contexts
+  %PROG% --sort=custom         : list the contexts in the order set with --move
+  %PROG% --auto-single         : switch to the context if it's the only one, otherwise list the
+  %SPAC%                         contexts (or set KUBECTX_AUTO_SINGLE=1)
+  %PROG% --no-headers          : list only the context names, without any decoration (for scripts)
   %PROG% <NAME>                : switch to context <NAME>
+  %PROG% <NAME> --strict       : switch to context <NAME>, only if it's an exact name match
+  %SPAC%                         (even if KUBECTX_FUZZY=1 enables substring matching)
+  %PROG% <NAME> --isolate      : switch to context <NAME>, and write it to its own kubeconfig
+  %SPAC%                         file (eval the output to point KUBECONFIG to it)
+  %PROG% <NAME> --warn-cross-project
+  %SPAC%                       : switch to context <NAME>, warning if its GCP project or AWS
+  %SPAC%                         account differs from the current context's
+  %PROG% <NAME> --yes, -y      : switch to context <NAME> without confirming, even if it matches
+  %SPAC%                         KUBECTX_DANGER_PATTERN (prod|production by default)
   %PROG% -                     : switch to the previous context
+  %PROG% --interactive-current : choose a context interactively, starting at the current context
+  %SPAC%                         (or set KUBECTX_INTERACTIVE_CURRENT=1, needs fzf 0.36+)
+  %PROG% --exec <NAME> -- <COMMAND...>
+  %SPAC%                       : run <COMMAND> against context <NAME> without switching to it
+  %SPAC%                         (using a temporary kubeconfig with only that context)
+  %PROG% --resolve <NAME> [--strict]
+  %SPAC%                       : show the context '%PROG% <NAME>' would switch to, without switching
+  %PROG% --peek                : show the context '%PROG% -' would switch to, without switching
+  %PROG% --query <TERM>        : interactively choose a context, with the search pre-filled
+  %SPAC%                         with <TERM> (can be repeated, not combinable with <NAME>)
   %PROG% -c, --current         : show the current context name
+  %PROG% --plain-current       : show the current context name, or nothing if it's not set
+  %PROG% --list-users [-o json]
+  %SPAC%                       : list the users in kubeconfig
+  %PROG% --list-clusters [-o json]
+  %SPAC%                       : list the clusters in kubeconfig, with their servers
+  %PROG% --graph               : print the contexts and the clusters and users they refer to as a
+  %SPAC%                         Graphviz DOT graph (e.g. "| dot -Tsvg > kubeconfig.svg")
+  %PROG% --tree [-o json]      : print the contexts grouped by cloud provider, project and cluster,
+  %SPAC%                         inferred from GKE and EKS names (others are listed under "other")
+  %PROG% --prune [--dry-run] [-y, --yes]
+  %SPAC%                       : remove the users and clusters no context refers to
+  %SPAC%                         (asks for confirmation unless -y, --dry-run only lists them)
+  %PROG% --prune-unused --older-than <DURATION> [--include-never-used] [--dry-run] [-y, --yes]
+  %SPAC%                       : delete the contexts not switched to within <DURATION> (e.g. 90d),
+  %SPAC%                         except the current and locked ones (asks for confirmation unless -y);
+  %SPAC%                         the ones with no history are only deleted with --include-never-used
+  %PROG% --watch-current [-o json]
+  %SPAC%                       : print the current context, and again whenever it changes
+  %PROG% --where [<NAME>]      : show the kubeconfig file defining context <NAME>
+  %PROG% --which-kubeconfig   : show the kubeconfig files in use, one per line in $KUBECONFIG order
+  %SPAC%                         (or the current context)
+  %PROG% --describe [<NAME>]   : show the namespace, kubeconfig file and note of context <NAME>
+  %PROG% --note <NAME> [<NOTE>]
+  %SPAC%                       : set the note of context <NAME> (or clear it without <NOTE>)
+  %PROG% --set-namespace <NS>  : change the active namespace of the current context to <NS>
+  %SPAC%                         ('-' for the previous namespace, same as kubens)
+  %PROG% --health [--timeout <DURATION>] [--concurrency <N>] [-o json]
+  %SPAC%                       : check if the cluster of each context is reachable
+  %SPAC%                         (defaults: 5s timeout, 8 contexts at a time, or
+  %SPAC%                         $KUBECTX_HEALTH_TIMEOUT and $KUBECTX_HEALTH_CONCURRENCY)
+  %PROG% --only-reachable [--timeout <DURATION>] [--concurrency <N>]
+  %SPAC%                       : list only the contexts whose cluster is reachable (same defaults)
+  %PROG% --group <GROUP>       : list (or choose interactively from) the contexts in <GROUP>, defined
+  %SPAC%                         in ~/.kube/kubectx-groups (combines with --sort, --only-reachable)
+  %PROG% --select <N> [--sort=<ORDER>] [--group <GROUP>] ...
+  %SPAC%                       : switch to the <N>th context of the list printed with the same flags
+  %PROG% --groups              : list the names of the context groups
+  %PROG% --since <DURATION>    : list the contexts used within <DURATION> (e.g. 1h), most recent first
+  %PROG% --last-n <N>          : list the <N> most recently used contexts, most recent first
+  %PROG% --show-last-used      : list the contexts with when they were last switched to (e.g. "2h ago")
+  %PROG% --show-source         : list the contexts with the kubeconfig file defining each
+  %PROG% --json, -o json       : list the contexts as JSON objects with "name", "current" (only for the
+  %SPAC%                         current context), and "lastUsed" and "source" with the flags above
+  %PROG% -o columns            : list the contexts with their namespace and server, aligned in
+  %SPAC%                         columns fitting the terminal (combines with the flags above)
+  %PROG% --require <PATTERN> [--require-namespace <NS_PATTERN>]
+  %SPAC%                       : fail unless the current context (and its namespace) match
+  %SPAC%                         the glob patterns, without switching (for CI pipelines)
   %PROG% <NEW_NAME>=<NAME>     : rename context <NAME> to <NEW_NAME>
+  %SPAC%                         (-f/--force also overwrites a locked <NEW_NAME>)
   %PROG% <NEW_NAME>=.          : rename current-context to <NEW_NAME>
+  %PROG% --validate-name <NAME>
+  %SPAC%                       : check if <NAME> can be used as a new context name
+  %PROG% --rename --interactive
+  %SPAC%                       : choose a context to rename, and enter its new name
+  %PROG% --rename-regex <PATTERN> <REPLACEMENT> [--dry-run]
+  %SPAC%                       : rename all contexts matching <PATTERN>
+  %SPAC%                         (--dry-run prints the new names without renaming)
+  %PROG% --rename-from-file <FILE> [--dry-run]
+  %SPAC%                       : rename the contexts following the "old=new" lines of <FILE>
+  %SPAC%                         ("-" for stdin), nothing is renamed if any name collides
+  %PROG% --normalize-names [--apply]
+  %SPAC%                       : preview renaming all contexts to lowercase, replacing the characters
+  %SPAC%                         other than letters, digits and '.' with '-' (--apply renames them)
+  %PROG% --rename-template <TEMPLATE> [<NAME...>] [--dry-run]
+  %SPAC%                       : rename contexts <NAME> (or all) to the names produced by
+  %SPAC%                         the Go template, e.g. '{{.Cluster}}-{{.Namespace}}'
+  %SPAC%                         (fields: .Name, .Cluster, .User, .Namespace)
+  %SPAC%                         (in a terminal, these ask for other names on collisions)
+  %PROG% --undo                : revert the last rename or delete done by %PROG%
   %PROG% -u, --unset           : unset the current context
+  %PROG% --move <NAME> <POS>   : move context <NAME> to position <POS> (from 1) in the custom
+  %SPAC%                         order, used by --sort=custom and interactive mode
+  %PROG% --touch <NAME>        : mark context <NAME> as recently used without switching
   %PROG% -d <NAME> [<NAME...>] : delete context <NAME> ('.' for current-context)
   %SPAC%                         (this command won't delete the user/cluster entry
   %SPAC%                          referenced by the context entry)
+  %PROG% -d --from-stdin       : delete the contexts named in stdin, one per line
+  %SPAC%                         (-f/--force also deletes locked contexts)
+  %PROG% --lock <NAME>         : protect context <NAME> from being deleted or overwritten
+  %PROG% --unlock <NAME>       : remove the lock from context <NAME>
+  %PROG% --locks               : list the locked contexts
+  %PROG% --print-completions-dir
+  %SPAC%                       : show where to install the completion scripts for your shell
+  %PROG% --print-fish-abbr     : print fish abbreviations (kx-<NAME>) switching to the contexts
+  %SPAC%                         ordered with --move, for "| source" in config.fish
+  %PROG% --ignore-case         : match context names regardless of case, if none matches exactly
+  %SPAC%                         (same as KUBECTX_IGNORE_CASE=1, can be combined with other flags)
+  %PROG% --no-color            : disable colored output (can be combined with other flags)
+  %PROG% --no-interactive      : never use fzf, even in a terminal (can be combined with other flags,
+  %SPAC%                         flags that need fzf like --query then fail)
+  %PROG% --out <FILE>          : write the list or current context to <FILE> instead of stdout
+  %SPAC%                         (the file is replaced atomically)
+  %PROG% --backup              : back up the kubeconfig before modifying it (can be combined
+  %SPAC%                         with other flags, see KUBECTX_BACKUP_DIR in README)
+  %PROG% --write-to <FILE>     : write current-context and new entries to <FILE> of the KUBECONFIG
+  %SPAC%                         files (can be combined with other flags)
+  %PROG% --config <FILE>       : read the defaults from <FILE> instead of
+  %SPAC%                         ~/.config/kubectx/config.yaml (can be combined with other flags)
+  %PROG% --stats [-o json]     : show how many times each context was switched to, most used first
+  %PROG% --reset-stats         : clear the context usage statistics
+  %PROG% --reset [-y, --yes]   : remove the state files of %PROG%, like the previous context and
+  %SPAC%                         history (asks for confirmation unless -y, kubeconfig is not modified)
   %PROG% -h,--help             : show this message
   %PROG% -V,--version          : show version`
 	help = strings.ReplaceAll(help, "%PROG%", selfName())
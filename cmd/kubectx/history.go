@@ -0,0 +1,132 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+// historyEntry records when a context was last used, and how many times it
+// has been switched to.
+type historyEntry struct {
+	Context  string    `json:"context"`
+	LastUsed time.Time `json:"lastUsed"`
+	Switches int       `json:"switches,omitempty"`
+}
+
+func kubectxHistoryFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.StateFile("history", filepath.Join(dir, "kubectx-history")), nil
+}
+
+// readHistory returns the saved history entries, most recently used first,
+// or nil if the history file doesn't exist.
+func readHistory(path string) ([]historyEntry, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var v []historyEntry
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, errors.Wrap(err, "failed to decode history file")
+	}
+	return v, nil
+}
+
+// writeHistory saves the history entries to the file.
+// It creates missing parent directories.
+func writeHistory(path string, entries []historyEntry) error {
+	path = cmdutil.WritableStateFile(path)
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode history")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create parent directories")
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// touchHistory moves the context to the top of the history entries,
+// marking it used at the specified time.
+func touchHistory(entries []historyEntry, ctx string, now time.Time) []historyEntry {
+	out := []historyEntry{{Context: ctx, LastUsed: now}}
+	for _, e := range entries {
+		if e.Context != ctx {
+			out = append(out, e)
+		} else {
+			out[0].Switches = e.Switches
+		}
+	}
+	return out
+}
+
+// renameInHistory is like renameInList, for history entries.
+func renameInHistory(entries []historyEntry, p renamePair) []historyEntry {
+	if p.Old == p.New {
+		return entries
+	}
+	var out []historyEntry
+	for _, e := range entries {
+		switch e.Context {
+		case p.New:
+			continue
+		case p.Old:
+			e.Context = p.New
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// recordContextUse marks the context as recently used in the history file.
+func recordContextUse(ctx string) error {
+	return updateHistory(ctx, false)
+}
+
+// recordContextSwitch is like recordContextUse, and also counts the switch
+// to the context for its usage statistics.
+func recordContextSwitch(ctx string) error {
+	return updateHistory(ctx, true)
+}
+
+func updateHistory(ctx string, switched bool) error {
+	path, err := kubectxHistoryFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine history file")
+	}
+	entries, err := readHistory(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read history")
+	}
+	entries = touchHistory(entries, ctx, time.Now())
+	if switched {
+		entries[0].Switches++
+	}
+	return writeHistory(path, entries)
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_readHistory_nonExistingFile(t *testing.T) {
+	v, err := readHistory(filepath.FromSlash("/non/existing/file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil {
+		t.Fatalf("expected nil history; got=%v", v)
+	}
+}
+
+func Test_writeHistory(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "history-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "foo", "history")
+
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := []historyEntry{{Context: "a", LastUsed: now}, {Context: "b", LastUsed: now.Add(-time.Hour)}}
+	if err := writeHistory(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("readHistory() diff=%s", diff)
+	}
+}
+
+func Test_touchHistory(t *testing.T) {
+	t0 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	entries := []historyEntry{{Context: "a", LastUsed: t0}, {Context: "b", LastUsed: t0, Switches: 2}}
+
+	got := touchHistory(entries, "b", t1)
+	want := []historyEntry{{Context: "b", LastUsed: t1, Switches: 2}, {Context: "a", LastUsed: t0}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("touchHistory() existing entry diff=%s", diff)
+	}
+
+	got = touchHistory(entries, "c", t1)
+	want = []historyEntry{{Context: "c", LastUsed: t1}, {Context: "a", LastUsed: t0}, {Context: "b", LastUsed: t0, Switches: 2}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("touchHistory() new entry diff=%s", diff)
+	}
+}
+
+func Test_recentContexts(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []historyEntry{
+		{Context: "a", LastUsed: now.Add(-time.Minute)},
+		{Context: "deleted", LastUsed: now.Add(-10 * time.Minute)},
+		{Context: "b", LastUsed: now.Add(-30 * time.Minute)},
+		{Context: "c", LastUsed: now.Add(-2 * time.Hour)},
+	}
+	ctxs := []string{"c", "b", "a", "unused"}
+
+	tests := []struct {
+		name  string
+		since time.Time
+		lastN int
+		want  []string
+	}{
+		{name: "all", want: []string{"a", "b", "c"}},
+		{name: "since", since: now.Add(-time.Hour), want: []string{"a", "b"}},
+		{name: "last n", lastN: 2, want: []string{"a", "b"}},
+		{name: "since and last n", since: now.Add(-time.Hour), lastN: 1, want: []string{"a"}},
+		{name: "none since", since: now, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recentContexts(ctxs, entries, tt.since, tt.lastN)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("recentContexts() diff=%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// runRenameHook runs the post-rename hook, if set. Failures of the hook only
+// print a warning, as the context is already renamed.
+func runRenameHook(stderr io.Writer, old, new string) {
+	if err := cmdutil.RunHook(stderr, env.EnvPostRenameHook, old, new); err != nil {
+		printer.Warning(stderr, "%s failed: %v", env.EnvPostRenameHook, err)
+	}
+}
+
+// runDeleteHook runs the post-delete hook, if set. Failures of the hook only
+// print a warning, as the context is already deleted.
+func runDeleteHook(stderr io.Writer, name string) {
+	if err := cmdutil.RunHook(stderr, env.EnvPostDeleteHook, name); err != nil {
+		printer.Warning(stderr, "%s failed: %v", env.EnvPostDeleteHook, err)
+	}
+}
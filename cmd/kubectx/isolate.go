@@ -0,0 +1,72 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// kubectxIsolatedFile returns the path of the single-context kubeconfig
+// file written for the context on an isolated switch.
+func kubectxIsolatedFile(ctx string) (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	// context names like EKS ARNs contain characters not valid in file names
+	fn := strings.NewReplacer("/", "_", "\\", "_", ":", "__").Replace(ctx)
+	return filepath.Join(dir, "kubectx-isolated", fn+".yaml"), nil
+}
+
+// writeIsolatedKubeconfig writes a kubeconfig file with only the context and
+// the cluster and user it refers to, and returns its path.
+func writeIsolatedKubeconfig(ctx string) (string, error) {
+	path, err := kubectxIsolatedFile(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to determine isolated kubeconfig file")
+	}
+	b, err := minifiedKubeconfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", errors.Wrap(err, "failed to create parent directories")
+	}
+	// the file has the user credentials, so it is only readable by the owner
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write isolated kubeconfig")
+	}
+	return path, nil
+}
+
+// minifiedKubeconfig returns a kubeconfig document with only the context and
+// the cluster and user it refers to.
+func minifiedKubeconfig(ctx string) ([]byte, error) {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return nil, errors.Wrap(err, "kubeconfig error")
+	}
+	b, err := kc.Minify(ctx)
+	return b, errors.Wrapf(err, "failed to extract context \"%s\"", ctx)
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// contextLastUsed is the time a context was last switched to, nil if it
+// never was.
+type contextLastUsed struct {
+	Name     string     `json:"name"`
+	LastUsed *time.Time `json:"lastUsed"`
+}
+
+// lastUsedTimes returns the time each of the contexts was last used
+// according to the history entries, in the order of ctxs.
+func lastUsedTimes(ctxs []string, entries []historyEntry) []contextLastUsed {
+	used := make(map[string]time.Time, len(entries))
+	for _, e := range entries {
+		used[e.Context] = e.LastUsed
+	}
+	out := make([]contextLastUsed, len(ctxs))
+	for i, c := range ctxs {
+		out[i].Name = c
+		if t, ok := used[c]; ok {
+			t = t.Truncate(time.Second) // RFC3339 without fractional seconds
+			out[i].LastUsed = &t
+		}
+	}
+	return out
+}
+
+// formatAgo describes how long before now t is, in its largest unit (e.g.
+// "2h ago"), or "never" if t is nil.
+func formatAgo(t *time.Time, now time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	d := now.Sub(*t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+}
+
+// printLastUsed prints the contexts with the time they were last used.
+func printLastUsed(w io.Writer, ctxs []contextLastUsed, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range ctxs {
+		fmt.Fprintf(tw, "%s\t%s\n", c.Name, formatAgo(c.LastUsed, now))
+	}
+	return errors.Wrap(tw.Flush(), "write error")
+}
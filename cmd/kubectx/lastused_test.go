@@ -0,0 +1,58 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_formatAgo(t *testing.T) {
+	now := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+	tests := []struct {
+		t    *time.Time
+		want string
+	}{
+		{nil, "never"},
+		{at(10 * time.Second), "just now"},
+		{at(5 * time.Minute), "5m ago"},
+		{at(2*time.Hour + 30*time.Minute), "2h ago"},
+		{at(3 * 24 * time.Hour), "3d ago"},
+	}
+	for _, tt := range tests {
+		if got := formatAgo(tt.t, now); got != tt.want {
+			t.Errorf("formatAgo(%v)=%q; want=%q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func Test_printLastUsed(t *testing.T) {
+	now := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	entries := []historyEntry{{Context: "b", LastUsed: now.Add(-2*time.Hour - 500*time.Millisecond)}}
+	ctxs := lastUsedTimes([]string{"a", "b"}, entries)
+
+	var buf bytes.Buffer
+	if err := printLastUsed(&buf, ctxs, now); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a  never\nb  2h ago\n"; buf.String() != want {
+		t.Errorf("output=%q; want=%q", buf.String(), want)
+	}
+	if got, want := ctxs[1].LastUsed.Format(time.RFC3339Nano), "2021-01-02T09:59:59Z"; got != want {
+		t.Errorf("lastUsed=%s; want=%s (truncated to seconds)", got, want)
+	}
+}
@@ -17,19 +17,266 @@ package main
 import (
 	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"facette.io/natsort"
 	"github.com/pkg/errors"
 
 	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
 	"github.com/ahmetb/kubectx/internal/kubeconfig"
 	"github.com/ahmetb/kubectx/internal/printer"
 )
 
 // ListOp describes listing contexts.
-type ListOp struct{}
+type ListOp struct {
+	Sort      string // "" for natural sort order of names, sortCustom or sortInteractive
+	NoHeaders bool   // print only the names, without highlighting the current context
 
-func (_ ListOp) Run(stdout, stderr io.Writer) error {
+	// AutoSingle makes listing switch to the context instead, if there's
+	// only one.
+	AutoSingle bool
+
+	// ReachableTimeout lists only the contexts whose API server responds
+	// within the timeout, if it's non-zero, probing ReachableConcurrency
+	// contexts at a time.
+	ReachableTimeout     time.Duration
+	ReachableConcurrency int
+
+	Group  string // list only the contexts in the group, if set
+	Output string // "" for names only, outputColumns or outputJSON
+
+	// ShowLastUsed and ShowSource print when each context was last switched
+	// to, and the kubeconfig file defining it.
+	ShowLastUsed bool
+	ShowSource   bool
+
+	// Since and LastN list only the contexts used within the duration, or
+	// the LastN most recently used ones, most recent first, if non-zero.
+	Since time.Duration
+	LastN int
+}
+
+// parseSortArg parses the --sort=<ORDER> flag.
+func parseSortArg(v string) Op {
+	order := strings.TrimPrefix(v, "--sort=")
+	if order != sortCustom && order != sortInteractive {
+		return UnsupportedOp{Err: fmt.Errorf("unsupported sort order %q", order)}
+	}
+	return ListOp{Sort: order}
+}
+
+// parseListFlags parses the listing flags starting with --only-reachable,
+// --group, -o, --json, --since, --last-n, --show-last-used, --show-source,
+// --no-headers or --sort=<ORDER>, in any order.
+func parseListFlags(argv []string) Op {
+	op, err := parseListOptions(argv)
+	if err != nil {
+		return UnsupportedOp{Err: err}
+	}
+	if op.Group != "" && op == (ListOp{Group: op.Group}) && cmdutil.IsInteractiveMode(os.Stdout) {
+		// only the group is given, pick from it interactively
+		return InteractiveSwitchOp{SelfCmd: os.Args[0], Group: op.Group}
+	}
+	return op
+}
+
+// parseListOptions parses the flags of listing in any order.
+func parseListOptions(argv []string) (ListOp, error) {
+	var op ListOp
+	var timeout time.Duration
+	var concurrency int
+	for i := 0; i < len(argv); i++ {
+		switch v := argv[i]; {
+		case v == "--only-reachable":
+			var err error
+			if op.ReachableTimeout, op.ReachableConcurrency, err = cmdutil.HealthDefaults(); err != nil {
+				return op, err
+			}
+		case v == "--group":
+			if i+1 >= len(argv) || argv[i+1] == "" {
+				return op, fmt.Errorf("'%s' needs a group name", v)
+			}
+			i++
+			op.Group = argv[i]
+		case v == "--no-headers":
+			op.NoHeaders = true
+		case v == "--show-last-used":
+			op.ShowLastUsed = true
+		case v == "--show-source":
+			op.ShowSource = true
+		case v == "--json":
+			op.Output = outputJSON
+		case v == "-o":
+			if i+1 >= len(argv) {
+				return op, fmt.Errorf("'%s' needs an argument", v)
+			}
+			i++
+			if argv[i] != outputColumns && argv[i] != outputJSON {
+				return op, fmt.Errorf("unsupported output format %q", argv[i])
+			}
+			op.Output = argv[i]
+		case v == "--since":
+			if i+1 >= len(argv) {
+				return op, fmt.Errorf("'%s' needs an argument", v)
+			}
+			i++
+			d, err := time.ParseDuration(argv[i])
+			if err != nil || d <= 0 {
+				return op, fmt.Errorf("invalid duration %q", argv[i])
+			}
+			op.Since = d
+		case v == "--last-n":
+			if i+1 >= len(argv) {
+				return op, fmt.Errorf("'%s' needs an argument", v)
+			}
+			i++
+			n, err := strconv.Atoi(argv[i])
+			if err != nil || n <= 0 {
+				return op, fmt.Errorf("invalid number of contexts %q", argv[i])
+			}
+			op.LastN = n
+		case strings.HasPrefix(v, "--sort="):
+			sortOp := parseSortArg(v)
+			l, ok := sortOp.(ListOp)
+			if !ok {
+				return op, sortOp.(UnsupportedOp).Err
+			}
+			op.Sort = l.Sort
+		case v == "--timeout":
+			if i+1 >= len(argv) {
+				return op, fmt.Errorf("'%s' needs an argument", v)
+			}
+			i++
+			d, err := time.ParseDuration(argv[i])
+			if err != nil || d <= 0 {
+				return op, fmt.Errorf("invalid timeout %q", argv[i])
+			}
+			timeout = d
+		case v == "--concurrency":
+			if i+1 >= len(argv) {
+				return op, fmt.Errorf("'%s' needs an argument", v)
+			}
+			i++
+			n, err := strconv.Atoi(argv[i])
+			if err != nil || n < 1 {
+				return op, fmt.Errorf("invalid concurrency %q", argv[i])
+			}
+			concurrency = n
+		default:
+			return op, fmt.Errorf("unsupported option '%s'", v)
+		}
+	}
+	if timeout > 0 {
+		if op.ReachableTimeout == 0 {
+			return op, fmt.Errorf("'--timeout' needs '--only-reachable'")
+		}
+		op.ReachableTimeout = timeout
+	}
+	if concurrency > 0 {
+		if op.ReachableTimeout == 0 {
+			return op, fmt.Errorf("'--concurrency' needs '--only-reachable'")
+		}
+		op.ReachableConcurrency = concurrency
+	}
+	if op.Output == outputColumns && (op.ShowLastUsed || op.ShowSource) {
+		return op, fmt.Errorf("'--show-last-used' and '--show-source' can't be combined with '-o columns'")
+	}
+	return op, nil
+}
+
+// recentContexts returns the contexts in the history entries (most recent
+// first) that exist in ctxs, used since the time if it's non-zero, at most
+// lastN of them if it's positive.
+func recentContexts(ctxs []string, entries []historyEntry, since time.Time, lastN int) []string {
+	exists := make(map[string]bool, len(ctxs))
+	for _, c := range ctxs {
+		exists[c] = true
+	}
+	var out []string
+	for _, e := range entries {
+		if lastN > 0 && len(out) == lastN {
+			break
+		}
+		if !since.IsZero() && e.LastUsed.Before(since) {
+			break // entries are sorted by LastUsed
+		}
+		if exists[e.Context] {
+			out = append(out, e.Context)
+		}
+	}
+	return out
+}
+
+// reachable returns the contexts whose API server responds within the
+// timeout, keeping their order.
+func reachable(ctxs []string, timeout time.Duration, concurrency int, probe probeFunc) []string {
+	var out []string
+	for _, r := range probeAll(ctxs, concurrency, timeout, probe) {
+		if r.Status == healthOK {
+			out = append(out, r.Context)
+		}
+	}
+	return out
+}
+
+// contexts returns the names of the contexts to list, in order, and the
+// history entries if they were needed to pick them.
+func (op ListOp) contexts(kc *kubeconfig.Kubeconfig) ([]string, []historyEntry, error) {
+	ctxs := kc.ContextNames()
+	if op.Sort == sortCustom {
+		path, err := kubectxOrderFile()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to determine state file")
+		}
+		order, err := readOrder(path)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read ordering file")
+		}
+		sortByOrder(ctxs, order)
+	} else if op.Sort == sortInteractive {
+		if err := sortInteractively(ctxs); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		natsort.Sort(ctxs)
+	}
+
+	var entries []historyEntry
+	if op.Since > 0 || op.LastN > 0 || op.ShowLastUsed {
+		path, err := kubectxHistoryFile()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to determine history file")
+		}
+		if entries, err = readHistory(path); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read history")
+		}
+	}
+	if op.Since > 0 || op.LastN > 0 {
+		var since time.Time
+		if op.Since > 0 {
+			since = time.Now().Add(-op.Since)
+		}
+		ctxs = recentContexts(ctxs, entries, since, op.LastN)
+	}
+	if op.Group != "" {
+		patterns, err := groupPatterns(op.Group)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctxs = filterGroup(ctxs, patterns)
+	}
+	if op.ReachableTimeout > 0 {
+		ctxs = reachable(ctxs, op.ReachableTimeout, op.ReachableConcurrency, kubeconfigProbe(kc))
+	}
+
+	return ctxs, entries, nil
+}
+
+func (op ListOp) Run(stdout, stderr io.Writer) error {
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
 	defer kc.Close()
 	if err := kc.Parse(); err != nil {
@@ -41,13 +288,62 @@ func (_ ListOp) Run(stdout, stderr io.Writer) error {
 	}
 
 	ctxs := kc.ContextNames()
-	natsort.Sort(ctxs)
+	if op.AutoSingle && len(ctxs) == 1 {
+		kc.Close()
+		if cmdutil.IsReadOnly() {
+			return cmdutil.ErrReadOnly
+		}
+		if err := confirmSwitch(stderr, ctxs[0], false); err != nil {
+			return errors.Wrap(err, "failed to switch context")
+		}
+		var oldCtx string
+		warn := cmdutil.IsCrossProjectWarning()
+		if warn {
+			oldCtx = currentContextName()
+		}
+		name, err := switchContext(ctxs[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to switch context")
+		}
+		audit(stderr, auditEntry{Operation: auditSwitch, Target: name})
+		err = printer.Success(stderr, "Switched to context \"%s\".", printer.SuccessColor.Sprint(name))
+		if warn {
+			warnCrossProject(stderr, oldCtx, name)
+		}
+		return errors.Wrap(err, "print error")
+	}
+	ctxs, entries, err := op.contexts(kc)
+	if err != nil {
+		return err
+	}
 
 	cur := kc.GetCurrentContext()
+	if op.Output == outputColumns {
+		var width int
+		if f, ok := stdout.(*os.File); ok {
+			width = cmdutil.TerminalWidth(f)
+		}
+		return printColumns(stdout, kc, ctxs, cur, op.NoHeaders, width)
+	}
+	if op.showsDetails() {
+		return op.printDetails(stdout, ctxs, listDetails{
+			cur:     cur,
+			entries: entries,
+			source:  kc.ContextSource,
+			now:     time.Now(),
+		})
+	}
+	display := displayNamer(stderr)
 	for _, c := range ctxs {
 		s := c
-		if c == cur {
-			s = printer.ActiveItemColor.Sprint(c)
+		if display != nil {
+			s = display(c)
+		}
+		if c == cur && !op.NoHeaders {
+			s = printer.ActiveItemColor.Sprint(s)
+		}
+		if display != nil && os.Getenv(env.EnvDisplayNames) != "" {
+			s = c + "\t" + s
 		}
 		fmt.Fprintf(stdout, "%s\n", s)
 	}
@@ -0,0 +1,130 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// jsonField is a field of a jsonObject.
+type jsonField struct {
+	Key   string
+	Value interface{}
+}
+
+// jsonObject is a JSON object encoded with its fields in the order they were
+// added, so that flags can add optional fields to the output without a struct
+// for each combination of them.
+type jsonObject []jsonField
+
+func (o jsonObject) with(key string, v interface{}) jsonObject {
+	return append(o, jsonField{Key: key, Value: v})
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode field %s", f.Key)
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// listDetails is what ListOp needs to print the details of the contexts.
+type listDetails struct {
+	cur     string
+	entries []historyEntry               // history, for ShowLastUsed
+	source  func(string) (string, error) // file defining a context, for ShowSource
+	now     time.Time
+}
+
+// showsDetails determines if the listing prints more than the names of the
+// contexts, one per line.
+func (op ListOp) showsDetails() bool {
+	return op.Output == outputJSON || op.ShowLastUsed || op.ShowSource
+}
+
+// printDetails prints the contexts with the details the flags ask for, in
+// columns highlighting the current context, or as a JSON array of objects. The objects always have the "name"
+// field, "current" (true) only for the current context, "lastUsed" (RFC3339,
+// or null if never used) with ShowLastUsed, and "source" with ShowSource, in
+// this order.
+func (op ListOp) printDetails(w io.Writer, ctxs []string, d listDetails) error {
+	var lastUsed []contextLastUsed
+	if op.ShowLastUsed {
+		lastUsed = lastUsedTimes(ctxs, d.entries)
+	}
+	objs := make([]jsonObject, len(ctxs))
+	rows := make([][]string, len(ctxs))
+	for i, c := range ctxs {
+		objs[i] = jsonObject{{Key: "name", Value: c}}
+		rows[i] = []string{c}
+		if c == d.cur {
+			objs[i] = objs[i].with("current", true)
+		}
+		if op.ShowLastUsed {
+			objs[i] = objs[i].with("lastUsed", lastUsed[i].LastUsed)
+			rows[i] = append(rows[i], formatAgo(lastUsed[i].LastUsed, d.now))
+		}
+		if op.ShowSource {
+			src, err := d.source(c)
+			if err != nil {
+				return errors.Wrapf(err, "failed to determine the file defining context \"%s\"", c)
+			}
+			objs[i] = objs[i].with("source", src)
+			rows[i] = append(rows[i], src)
+		}
+	}
+
+	if op.Output == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(objs), "write error")
+	}
+	widths := fitColumns(rows, 0)
+	for i, r := range rows {
+		var highlight func(string) string
+		if ctxs[i] == d.cur && !op.NoHeaders {
+			highlight = func(s string) string { return printer.ActiveItemColor.Sprint(s) }
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(formatRow(r, widths, highlight), " ")); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
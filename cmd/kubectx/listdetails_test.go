@@ -0,0 +1,144 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListOp_printDetails_json(t *testing.T) {
+	now := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	d := listDetails{
+		cur:     "a",
+		entries: []historyEntry{{Context: "a", LastUsed: now.Add(-time.Hour)}},
+		source:  func(name string) (string, error) { return "/kube/" + name, nil },
+		now:     now,
+	}
+
+	tests := []struct {
+		name string
+		op   ListOp
+		want [][]string // keys of the object of each context, in order
+	}{
+		{
+			name: "no details",
+			op:   ListOp{Output: outputJSON},
+			want: [][]string{{"name", "current"}, {"name"}},
+		},
+		{
+			name: "last used",
+			op:   ListOp{Output: outputJSON, ShowLastUsed: true},
+			want: [][]string{{"name", "current", "lastUsed"}, {"name", "lastUsed"}},
+		},
+		{
+			name: "source",
+			op:   ListOp{Output: outputJSON, ShowSource: true},
+			want: [][]string{{"name", "current", "source"}, {"name", "source"}},
+		},
+		{
+			name: "all details",
+			op:   ListOp{Output: outputJSON, ShowSource: true, ShowLastUsed: true},
+			want: [][]string{{"name", "current", "lastUsed", "source"}, {"name", "lastUsed", "source"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.op.printDetails(&buf, []string{"a", "b"}, d); err != nil {
+				t.Fatal(err)
+			}
+			var objs []json.RawMessage
+			if err := json.Unmarshal(buf.Bytes(), &objs); err != nil {
+				t.Fatalf("invalid json %s: %v", buf.String(), err)
+			}
+			var got [][]string
+			for _, o := range objs {
+				got = append(got, objectKeys(t, o))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("keys diff=%s", diff)
+			}
+		})
+	}
+}
+
+// objectKeys returns the keys of the JSON object in the order they appear.
+func objectKeys(t *testing.T, b []byte) []string {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if _, err := dec.Token(); err != nil { // {
+		t.Fatal(err)
+	}
+	var keys []string
+	for dec.More() {
+		k, err := dec.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, k.(string))
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return keys
+}
+
+func TestListOp_printDetails(t *testing.T) {
+	now := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	d := listDetails{
+		cur:     "a",
+		entries: []historyEntry{{Context: "long-name", LastUsed: now.Add(-2 * time.Hour)}},
+		source:  func(name string) (string, error) { return "/kube/config", nil },
+		now:     now,
+	}
+
+	var buf bytes.Buffer
+	op := ListOp{ShowLastUsed: true, ShowSource: true, NoHeaders: true}
+	if err := op.printDetails(&buf, []string{"a", "long-name"}, d); err != nil {
+		t.Fatal(err)
+	}
+	want := "a          never   /kube/config\n" +
+		"long-name  2h ago  /kube/config\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("output diff=%s", diff)
+	}
+
+	buf.Reset()
+	op = ListOp{Output: outputJSON, ShowLastUsed: true}
+	if err := op.printDetails(&buf, []string{"a", "long-name"}, d); err != nil {
+		t.Fatal(err)
+	}
+	want = `[
+  {
+    "name": "a",
+    "current": true,
+    "lastUsed": null
+  },
+  {
+    "name": "long-name",
+    "lastUsed": "2021-01-02T10:00:00Z"
+  }
+]
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("json output diff=%s", diff)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// LockOp indicates intention to protect a context from deletion.
+type LockOp struct {
+	Context string
+}
+
+// UnlockOp indicates intention to remove the lock of a context.
+type UnlockOp struct {
+	Context string
+}
+
+// ListLocksOp indicates intention to list the locked contexts.
+type ListLocksOp struct{}
+
+func kubectxLocksFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.StateFile("locks", filepath.Join(dir, "kubectx-locks")), nil
+}
+
+// readLocks returns the names of the locked contexts. The locks file has the
+// same format as the ordering file, one context name per line.
+func readLocks() ([]string, error) {
+	path, err := kubectxLocksFile()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine state file")
+	}
+	locks, err := readOrder(path)
+	return locks, errors.Wrap(err, "failed to read locks file")
+}
+
+func writeLocks(locks []string) error {
+	path, err := kubectxLocksFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	return errors.Wrap(writeOrder(path, locks), "failed to save locks file")
+}
+
+func lockSet(locks []string) map[string]bool {
+	out := make(map[string]bool, len(locks))
+	for _, v := range locks {
+		out[v] = true
+	}
+	return out
+}
+
+// addLock returns the locks with ctx added, sorted naturally, and whether
+// ctx was not already locked.
+func addLock(locks []string, ctx string) ([]string, bool) {
+	if slices.Contains(locks, ctx) {
+		return locks, false
+	}
+	out := append(slices.Clone(locks), ctx)
+	natsort.Sort(out)
+	return out, true
+}
+
+// removeLock returns the locks without ctx, and whether ctx was locked.
+func removeLock(locks []string, ctx string) ([]string, bool) {
+	i := slices.Index(locks, ctx)
+	if i < 0 {
+		return locks, false
+	}
+	return slices.Delete(slices.Clone(locks), i, i+1), true
+}
+
+func (op LockOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	if !kc.ContextExists(op.Context) {
+		return errors.Errorf("no context exists with the name: \"%s\"", op.Context)
+	}
+
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+	locks, added := addLock(locks, op.Context)
+	if !added {
+		printer.Warning(stderr, "context \"%s\" is already locked", op.Context)
+		return nil
+	}
+	if err := writeLocks(locks); err != nil {
+		return err
+	}
+	err = printer.Success(stderr, "Locked context \"%s\".", printer.SuccessColor.Sprint(op.Context))
+	return errors.Wrap(err, "print error")
+}
+
+func (op UnlockOp) Run(_, stderr io.Writer) error {
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+	locks, removed := removeLock(locks, op.Context)
+	if !removed {
+		printer.Warning(stderr, "context \"%s\" is not locked", op.Context)
+		return nil
+	}
+	if err := writeLocks(locks); err != nil {
+		return err
+	}
+	err = printer.Success(stderr, "Unlocked context \"%s\".", printer.SuccessColor.Sprint(op.Context))
+	return errors.Wrap(err, "print error")
+}
+
+func (_ ListLocksOp) Run(stdout, _ io.Writer) error {
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+	for _, v := range locks {
+		if _, err := fmt.Fprintln(stdout, v); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_addLock(t *testing.T) {
+	tests := []struct {
+		locks     []string
+		ctx       string
+		want      []string
+		wantAdded bool
+	}{
+		{nil, "a", []string{"a"}, true},
+		{[]string{"a", "c"}, "b", []string{"a", "b", "c"}, true},
+		{[]string{"ctx2"}, "ctx10", []string{"ctx2", "ctx10"}, true},
+		{[]string{"a", "b"}, "b", []string{"a", "b"}, false},
+	}
+	for _, tt := range tests {
+		got, added := addLock(tt.locks, tt.ctx)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("addLock(%v, %q) diff=%s", tt.locks, tt.ctx, diff)
+		}
+		if added != tt.wantAdded {
+			t.Errorf("addLock(%v, %q) added=%v; want=%v", tt.locks, tt.ctx, added, tt.wantAdded)
+		}
+	}
+}
+
+func Test_removeLock(t *testing.T) {
+	tests := []struct {
+		locks       []string
+		ctx         string
+		want        []string
+		wantRemoved bool
+	}{
+		{nil, "a", nil, false},
+		{[]string{"a", "b", "c"}, "b", []string{"a", "c"}, true},
+		{[]string{"a"}, "a", []string{}, true},
+		{[]string{"a"}, "b", []string{"a"}, false},
+	}
+	for _, tt := range tests {
+		got, removed := removeLock(tt.locks, tt.ctx)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("removeLock(%v, %q) diff=%s", tt.locks, tt.ctx, diff)
+		}
+		if removed != tt.wantRemoved {
+			t.Errorf("removeLock(%v, %q) removed=%v; want=%v", tt.locks, tt.ctx, removed, tt.wantRemoved)
+		}
+	}
+}
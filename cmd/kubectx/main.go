@@ -18,9 +18,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 
 	"github.com/ahmetb/kubectx/internal/cmdutil"
 	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
 	"github.com/ahmetb/kubectx/internal/printer"
 	"github.com/fatih/color"
 )
@@ -32,8 +34,65 @@ type Op interface {
 func main() {
 	cmdutil.PrintDeprecatedEnvWarnings(color.Error, os.Environ())
 
-	op := parseArgs(os.Args[1:])
+	// flags after "--" belong to the command run by --exec
+	argv, cmdArgs := os.Args[1:], []string(nil)
+	if i := slices.Index(argv, "--"); i >= 0 {
+		argv, cmdArgs = argv[:i], argv[i:]
+	}
+
+	args, configFile, err := cmdutil.StripFlagValue(argv, "--config")
+	var cfg, prefs cmdutil.Config
+	if _, extErr := kubeconfig.DefaultExtension(cmdutil.ConfigExtension, &prefs); extErr != nil {
+		// a bad extension must not break every command, its valid fields are used
+		printer.Warning(color.Error, "%v", extErr)
+	}
+	if err == nil {
+		cfg, err = cmdutil.LoadConfig(configFile, prefs)
+	}
+
+	args, noColor := cmdutil.StripFlag(args, "--no-color")
+	if noColor || cfg.ColorDisabled() {
+		printer.DisableColors()
+	}
+	args, ignoreCase := cmdutil.StripFlag(args, "--ignore-case")
+	if ignoreCase {
+		os.Setenv(env.EnvIgnoreCase, "1")
+	}
+	args, noInteractive := cmdutil.StripFlag(args, "--no-interactive")
+	if noInteractive {
+		cmdutil.DisableInteractiveMode()
+	}
+	args, backup := cmdutil.StripFlag(args, "--backup")
+	if backup {
+		kubeconfig.EnableBackups()
+	}
+
+	args, outFile, outErr := cmdutil.StripFlagValue(args, "--out")
+	if err == nil {
+		err = outErr
+	}
+	args, writeTo, writeToErr := cmdutil.StripFlagValue(args, "--write-to")
+	if err == nil {
+		err = writeToErr
+	}
+	if err == nil && writeTo != "" {
+		err = kubeconfig.SetWriteTo(writeTo)
+	}
+
+	op := parseArgs(append(args, cmdArgs...))
+	if err != nil {
+		op = UnsupportedOp{Err: err}
+	} else if outFile != "" {
+		op = withOutFile(op, outFile)
+	}
+	if cmdutil.IsReadOnly() && !readOnly(op) {
+		op = UnsupportedOp{Err: cmdutil.ErrReadOnly}
+	}
 	if err := op.Run(color.Output, color.Error); err != nil {
+		if ee, ok := err.(cmdutil.ExitCodeError); ok {
+			defer os.Exit(ee.Code)
+			return
+		}
 		printer.Error(color.Error, err.Error())
 
 		if _, ok := os.LookupEnv(env.EnvDebug); ok {
@@ -0,0 +1,102 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// NormalizeOp indicates intention to rename all contexts to lowercase names
+// made of letters, digits, '-' and '.'.
+type NormalizeOp struct {
+	Apply bool // rename the contexts, instead of only printing the new names
+}
+
+// parseNormalizeArgs parses the arguments following --normalize-names.
+func parseNormalizeArgs(argv []string) Op {
+	var op NormalizeOp
+	for _, v := range argv {
+		switch v {
+		case "--apply":
+			op.Apply = true
+		case "--dry-run":
+			// the default, accepted for consistency with the other renames
+		default:
+			return UnsupportedOp{Err: fmt.Errorf("unsupported option '%s'", v)}
+		}
+	}
+	return op
+}
+
+// normalizeName returns the name in lowercase, with each run of characters
+// other than letters, digits and '.' replaced by a single '-', and without
+// leading or trailing '-'.
+func normalizeName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+func (op NormalizeOp) Run(stdout, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	names := kc.ContextNames()
+	plan, err := renamePlan(names, names, func(old string) (string, error) {
+		return normalizeName(old), nil
+	})
+	if err != nil {
+		if plan, err = resolveInteractively(stderr, kc, err, !op.Apply); err != nil {
+			return err
+		}
+	}
+	if len(plan) == 0 {
+		return printer.Success(stderr, "All %d context names are already normalized.", len(names))
+	}
+
+	if !op.Apply {
+		for _, p := range plan {
+			fmt.Fprintf(stdout, "%s -> %s\n", p.Old, p.New)
+		}
+		return printer.Success(stderr, "%d of %d contexts would be renamed, use --apply to rename them.",
+			len(plan), len(names))
+	}
+	if err := applyRenames(stderr, kc, plan); err != nil {
+		return err
+	}
+	return printer.Success(stderr, "Normalized %d of %d contexts (%d unchanged).",
+		len(plan), len(names), len(names)-len(plan))
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func Test_normalizeName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"minikube", "minikube"},
+		{"Prod", "prod"},
+		{"gke_my-project_us-east1_Prod", "gke-my-project-us-east1-prod"},
+		{"arn:aws:eks:us-east-1:123:cluster/Main", "arn-aws-eks-us-east-1-123-cluster-main"},
+		{"admin@cluster.local", "admin-cluster.local"},
+		{"__a  b__", "a-b"},
+		{"@@", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeName(tt.in); got != tt.want {
+			t.Errorf("normalizeName(%q)=%q; want=%q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// maxNoteLength is the maximum number of characters in a context note.
+const maxNoteLength = 200
+
+// NoteOp indicates intention to set the note of a context, or clear it if
+// Note is empty.
+type NoteOp struct {
+	Context string
+	Note    string
+}
+
+// parseNoteArgs parses the arguments following --note.
+func parseNoteArgs(argv []string) Op {
+	switch len(argv) {
+	case 1:
+		return NoteOp{Context: argv[0]}
+	case 2:
+		return NoteOp{Context: argv[0], Note: argv[1]}
+	default:
+		return UnsupportedOp{Err: fmt.Errorf("'--note' needs a context name and an optional note")}
+	}
+}
+
+// validateNote returns an error if the note isn't valid UTF-8 or is too long.
+func validateNote(note string) error {
+	if !utf8.ValidString(note) {
+		return errors.New("note is not valid UTF-8")
+	}
+	if n := utf8.RuneCountInString(note); n > maxNoteLength {
+		return errors.Errorf("note is %d characters long, the limit is %d", n, maxNoteLength)
+	}
+	return nil
+}
+
+func kubectxNotesFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.StateFile("notes", filepath.Join(dir, "kubectx-notes")), nil
+}
+
+// readNotes returns the notes of the contexts by name, or nil if there are
+// none.
+func readNotes(path string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var v map[string]string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, errors.Wrap(err, "failed to decode notes file")
+	}
+	return v, nil
+}
+
+// writeNotes saves the notes of the contexts. It creates missing parent
+// directories.
+func writeNotes(path string, notes map[string]string) error {
+	path = cmdutil.WritableStateFile(path)
+	b, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode notes")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create parent directories")
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// contextNote returns the note of the context, or "" if it has none.
+func contextNote(name string) (string, error) {
+	path, err := kubectxNotesFile()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to determine state file")
+	}
+	notes, err := readNotes(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read notes file")
+	}
+	return notes[name], nil
+}
+
+func (op NoteOp) Run(_, stderr io.Writer) error {
+	if err := validateNote(op.Note); err != nil {
+		return err
+	}
+
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	if !kc.ContextExists(op.Context) {
+		return errors.Errorf("no context exists with the name: \"%s\"", op.Context)
+	}
+
+	path, err := kubectxNotesFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	notes, err := readNotes(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read notes file")
+	}
+	if notes == nil {
+		notes = make(map[string]string)
+	}
+	if op.Note == "" {
+		delete(notes, op.Context)
+	} else {
+		notes[op.Context] = op.Note
+	}
+	if err := writeNotes(path, notes); err != nil {
+		return errors.Wrap(err, "failed to save notes file")
+	}
+
+	if op.Note == "" {
+		err = printer.Success(stderr, "Cleared the note of context \"%s\".", printer.SuccessColor.Sprint(op.Context))
+	} else {
+		err = printer.Success(stderr, "Saved the note of context \"%s\".", printer.SuccessColor.Sprint(op.Context))
+	}
+	return errors.Wrap(err, "print error")
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_validateNote(t *testing.T) {
+	tests := []struct {
+		note    string
+		wantErr bool
+	}{
+		{"", false},
+		{"prod cluster, be careful", false},
+		{"überprüfen Sie zweimal ⚠️", false},
+		{strings.Repeat("ü", maxNoteLength), false},
+		{strings.Repeat("a", maxNoteLength+1), true},
+		{"invalid \xff utf-8", true},
+	}
+	for _, tt := range tests {
+		err := validateNote(tt.note)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateNote(%q) err=%v; wantErr=%v", tt.note, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_writeNotes(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "notes-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "foo", "notes")
+
+	v, err := readNotes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil {
+		t.Fatalf("expected nil notes; got=%v", v)
+	}
+
+	want := map[string]string{"prod": "be careful", "dev": "ok"}
+	if err := writeNotes(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readNotes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("readNotes() diff=%s", diff)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+const (
+	// sortCustom is the ListOp sort order following the user-defined ordering.
+	sortCustom = "custom"
+	// sortInteractive is the ListOp sort order of the interactive mode,
+	// composed of the orderings in defaultInteractiveOrder.
+	sortInteractive = "interactive"
+)
+
+const (
+	orderUser   = "order"  // the user-defined ordering
+	orderRecent = "recent" // the recently used contexts
+)
+
+// defaultInteractiveOrder is the ordering of the interactive mode unless
+// overridden via the KUBECTX_INTERACTIVE_ORDER environment variable.
+var defaultInteractiveOrder = []string{orderUser, orderRecent}
+
+// MoveOp indicates intention to move a context to a position in the
+// user-defined ordering.
+type MoveOp struct {
+	Context  string
+	Position int // 1-based
+}
+
+// parseMoveArgs parses the arguments following --move.
+func parseMoveArgs(argv []string) Op {
+	if len(argv) != 2 {
+		return UnsupportedOp{Err: fmt.Errorf("'--move' needs a context name and a position")}
+	}
+	pos, err := strconv.Atoi(argv[1])
+	if err != nil || pos < 1 {
+		return UnsupportedOp{Err: fmt.Errorf("invalid position %q, must be a number starting from 1", argv[1])}
+	}
+	return MoveOp{Context: argv[0], Position: pos}
+}
+
+func kubectxOrderFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.StateFile("order", filepath.Join(dir, "kubectx-order")), nil
+}
+
+// readOrder returns the context names in the user-defined order, or nil if
+// the ordering file doesn't exist.
+func readOrder(path string) ([]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, l := range strings.Split(string(b), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// writeOrder saves the context names in order, one per line.
+// It creates missing parent directories.
+func writeOrder(path string, order []string) error {
+	path = cmdutil.WritableStateFile(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create parent directories")
+	}
+	var b strings.Builder
+	for _, v := range order {
+		b.WriteString(v + "\n")
+	}
+	return ioutil.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// moveInOrder moves (or inserts) the context to the 1-based position in the
+// order. Positions past the end append the context.
+func moveInOrder(order []string, ctx string, pos int) []string {
+	out := make([]string, 0, len(order)+1)
+	for _, v := range order {
+		if v != ctx {
+			out = append(out, v)
+		}
+	}
+	i := pos - 1
+	if i > len(out) {
+		i = len(out)
+	}
+	out = append(out, "")
+	copy(out[i+1:], out[i:])
+	out[i] = ctx
+	return out
+}
+
+// sortByOrder sorts the context names in place following the order. Names
+// without a position come after the ordered ones, sorted naturally.
+func sortByOrder(names, order []string) {
+	pos := make(map[string]int, len(order))
+	for i, v := range order {
+		if _, ok := pos[v]; !ok {
+			pos[v] = i
+		}
+	}
+	var ordered, rest []string
+	for _, n := range names {
+		if _, ok := pos[n]; ok {
+			ordered = append(ordered, n)
+		} else {
+			rest = append(rest, n)
+		}
+	}
+	natsort.Sort(rest)
+	sort.Slice(ordered, func(i, j int) bool { return pos[ordered[i]] < pos[ordered[j]] })
+	copy(names, append(ordered, rest...))
+}
+
+// interactiveOrderComponents returns the orderings to compose for the
+// interactive mode from the environment variable value, or the default ones
+// if it's not set.
+func interactiveOrderComponents(v string, set bool) ([]string, error) {
+	if !set {
+		return defaultInteractiveOrder, nil
+	}
+	var out []string
+	for _, c := range strings.Split(v, ",") {
+		switch c = strings.TrimSpace(c); c {
+		case "":
+		case orderUser, orderRecent:
+			out = append(out, c)
+		default:
+			return nil, errors.Errorf("unsupported ordering %q in %s, must be %q or %q",
+				c, env.EnvInteractiveOrder, orderUser, orderRecent)
+		}
+	}
+	return out, nil
+}
+
+// composeOrder sorts the context names in place by each of the orderings
+// in turn: the names in the first ordering come first in its order, then the
+// names in the next ordering that aren't listed yet, and so on. The rest of
+// the names come last, sorted naturally.
+func composeOrder(names []string, orderings ...[]string) {
+	var order []string
+	seen := make(map[string]bool)
+	for _, o := range orderings {
+		for _, v := range o {
+			if !seen[v] {
+				seen[v] = true
+				order = append(order, v)
+			}
+		}
+	}
+	sortByOrder(names, order)
+}
+
+// sortInteractively sorts the context names in place for the interactive
+// mode, following the orderings configured via KUBECTX_INTERACTIVE_ORDER.
+func sortInteractively(names []string) error {
+	components, err := interactiveOrderComponents(os.LookupEnv(env.EnvInteractiveOrder))
+	if err != nil {
+		return err
+	}
+	var orderings [][]string
+	for _, c := range components {
+		switch c {
+		case orderUser:
+			path, err := kubectxOrderFile()
+			if err != nil {
+				return errors.Wrap(err, "failed to determine state file")
+			}
+			order, err := readOrder(path)
+			if err != nil {
+				return errors.Wrap(err, "failed to read ordering file")
+			}
+			orderings = append(orderings, order)
+		case orderRecent:
+			path, err := kubectxHistoryFile()
+			if err != nil {
+				return errors.Wrap(err, "failed to determine history file")
+			}
+			entries, err := readHistory(path)
+			if err != nil {
+				return errors.Wrap(err, "failed to read history")
+			}
+			recent := make([]string, len(entries))
+			for i, e := range entries {
+				recent[i] = e.Context
+			}
+			orderings = append(orderings, recent)
+		}
+	}
+	composeOrder(names, orderings...)
+	return nil
+}
+
+func (op MoveOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	if !kc.ContextExists(op.Context) {
+		return errors.Errorf("no context exists with the name: \"%s\"", op.Context)
+	}
+
+	path, err := kubectxOrderFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	order, err := readOrder(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read ordering file")
+	}
+	order = moveInOrder(order, op.Context, op.Position)
+	if err := writeOrder(path, order); err != nil {
+		return errors.Wrap(err, "failed to save ordering file")
+	}
+	err = printer.Success(stderr, "Moved context \"%s\" to position %d.",
+		printer.SuccessColor.Sprint(op.Context), slices.Index(order, op.Context)+1)
+	return errors.Wrap(err, "print error")
+}
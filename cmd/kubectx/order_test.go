@@ -0,0 +1,122 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_readOrder_nonExistingFile(t *testing.T) {
+	v, err := readOrder(filepath.FromSlash("/non/existing/file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil {
+		t.Fatalf("expected nil order; got=%v", v)
+	}
+}
+
+func Test_writeOrder(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "order-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "foo", "order")
+
+	want := []string{"b", "a"}
+	if err := writeOrder(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readOrder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("readOrder() diff=%s", diff)
+	}
+}
+
+func Test_moveInOrder(t *testing.T) {
+	tests := []struct {
+		order []string
+		ctx   string
+		pos   int
+		want  []string
+	}{
+		{order: nil, ctx: "a", pos: 1, want: []string{"a"}},
+		{order: nil, ctx: "a", pos: 3, want: []string{"a"}},
+		{order: []string{"a", "b", "c"}, ctx: "c", pos: 1, want: []string{"c", "a", "b"}},
+		{order: []string{"a", "b", "c"}, ctx: "a", pos: 2, want: []string{"b", "a", "c"}},
+		{order: []string{"a", "b", "c"}, ctx: "a", pos: 10, want: []string{"b", "c", "a"}},
+		{order: []string{"a", "b"}, ctx: "d", pos: 2, want: []string{"a", "d", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%s/%d", tt.order, tt.ctx, tt.pos), func(t *testing.T) {
+			got := moveInOrder(tt.order, tt.ctx, tt.pos)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("moveInOrder() diff=%s", diff)
+			}
+		})
+	}
+}
+
+func Test_sortByOrder(t *testing.T) {
+	names := []string{"ctx10", "b", "ctx2", "a", "c"}
+	sortByOrder(names, []string{"c", "removed", "a"})
+	want := []string{"c", "a", "b", "ctx2", "ctx10"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Fatalf("sortByOrder() diff=%s", diff)
+	}
+}
+
+func Test_composeOrder(t *testing.T) {
+	names := []string{"ctx10", "b", "ctx2", "a", "c", "d"}
+	composeOrder(names, []string{"c", "a"}, []string{"d", "a", "removed"})
+	want := []string{"c", "a", "d", "b", "ctx2", "ctx10"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Fatalf("composeOrder() diff=%s", diff)
+	}
+}
+
+func Test_interactiveOrderComponents(t *testing.T) {
+	tests := []struct {
+		value   string
+		set     bool
+		want    []string
+		wantErr bool
+	}{
+		{set: false, want: []string{"order", "recent"}},
+		{value: "", set: true, want: nil},
+		{value: "recent", set: true, want: []string{"recent"}},
+		{value: "recent, order", set: true, want: []string{"recent", "order"}},
+		{value: "bookmarks", set: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := interactiveOrderComponents(tt.value, tt.set)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("interactiveOrderComponents(%q) err=%v, wantErr=%v", tt.value, err, tt.wantErr)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("interactiveOrderComponents(%q) diff=%s", tt.value, diff)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// cloudProject is the cloud project or account a context belongs to.
+type cloudProject struct {
+	Kind string // e.g. "GCP project"
+	ID   string
+}
+
+// cloudCluster is a cluster of a cloud provider, as inferred from a context or
+// cluster name.
+type cloudCluster struct {
+	Provider string // "GKE" or "EKS"
+	Project  string // the GCP project, or the AWS account
+	Location string // the zone or region
+	Name     string
+}
+
+// projectKinds are the kinds of the projects of each provider.
+var projectKinds = map[string]string{
+	"GKE": "GCP project",
+	"EKS": "AWS account",
+}
+
+// parseCloudCluster determines the cloud cluster from a context or cluster
+// name in the formats written by the cloud provider CLIs:
+//
+//	gke_<PROJECT>_<LOCATION>_<CLUSTER>           (gcloud)
+//	arn:<PARTITION>:eks:<REGION>:<ACCOUNT>:cluster/<CLUSTER>  (aws)
+func parseCloudCluster(name string) (cloudCluster, bool) {
+	if p := strings.SplitN(name, "_", 4); len(p) == 4 && p[0] == "gke" && p[1] != "" {
+		return cloudCluster{Provider: "GKE", Project: p[1], Location: p[2], Name: p[3]}, true
+	}
+	if p := strings.SplitN(name, ":", 6); len(p) == 6 && p[0] == "arn" && p[2] == "eks" && p[4] != "" {
+		return cloudCluster{Provider: "EKS", Project: p[4], Location: p[3],
+			Name: strings.TrimPrefix(p[5], "cluster/")}, true
+	}
+	return cloudCluster{}, false
+}
+
+// parseCloudProject determines the cloud project or account from a context
+// or cluster name, see parseCloudCluster.
+func parseCloudProject(name string) (cloudProject, bool) {
+	c, ok := parseCloudCluster(name)
+	if !ok {
+		return cloudProject{}, false
+	}
+	return cloudProject{Kind: projectKinds[c.Provider], ID: c.Project}, true
+}
+
+// contextProject determines the cloud project of the context from its name,
+// or from the name of its cluster if the context was renamed.
+func contextProject(kc *kubeconfig.Kubeconfig, ctx string) (cloudProject, bool) {
+	c, ok := contextCluster(kc, ctx)
+	if !ok {
+		return cloudProject{}, false
+	}
+	return cloudProject{Kind: projectKinds[c.Provider], ID: c.Project}, true
+}
+
+// contextCluster determines the cloud cluster of the context from its name,
+// or from the name of its cluster if the context was renamed.
+func contextCluster(kc *kubeconfig.Kubeconfig, ctx string) (cloudCluster, bool) {
+	if c, ok := parseCloudCluster(ctx); ok {
+		return c, true
+	}
+	cluster, err := kc.ClusterOfContext(ctx)
+	if err != nil {
+		return cloudCluster{}, false
+	}
+	return parseCloudCluster(cluster)
+}
+
+// warnCrossProject prints a warning if the contexts belong to different
+// cloud projects. Contexts whose project can't be determined are ignored.
+func warnCrossProject(stderr io.Writer, from, to string) {
+	if from == "" || from == to {
+		return
+	}
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return
+	}
+	fp, ok := contextProject(kc, from)
+	if !ok {
+		return
+	}
+	tp, ok := contextProject(kc, to)
+	if !ok || fp == tp {
+		return
+	}
+	printer.Warning(stderr, "switched from %s \"%s\" to %s \"%s\"", fp.Kind, fp.ID,
+		tp.Kind, printer.WarningColor.Sprint(tp.ID))
+}
+
+// currentContextName returns the current-context, or "" if it can't be
+// determined.
+func currentContextName() string {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return ""
+	}
+	return kc.GetCurrentContext()
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_parseCloudProject(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   cloudProject
+		wantOk bool
+	}{
+		{"gke_my-project_us-central1-a_prod", cloudProject{"GCP project", "my-project"}, true},
+		{"gke_my-project_us-central1_cluster_with_underscores", cloudProject{"GCP project", "my-project"}, true},
+		{"arn:aws:eks:us-east-1:123456789012:cluster/prod", cloudProject{"AWS account", "123456789012"}, true},
+		{"arn:aws-cn:eks:cn-north-1:123456789012:cluster/prod", cloudProject{"AWS account", "123456789012"}, true},
+		{"gke_my-project", cloudProject{}, false},
+		{"arn:aws:iam::123456789012:user/admin", cloudProject{}, false},
+		{"minikube", cloudProject{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseCloudProject(tt.name)
+		if ok != tt.wantOk || got != tt.want {
+			t.Errorf("parseCloudProject(%q)=%v,%v; expected=%v,%v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func Test_parseCloudCluster(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   cloudCluster
+		wantOk bool
+	}{
+		{"gke_my-project_us-central1-a_prod", cloudCluster{"GKE", "my-project", "us-central1-a", "prod"}, true},
+		{"gke_my-project_us-central1_cluster_with_underscores", cloudCluster{"GKE", "my-project", "us-central1", "cluster_with_underscores"}, true},
+		{"arn:aws:eks:us-east-1:123456789012:cluster/prod", cloudCluster{"EKS", "123456789012", "us-east-1", "prod"}, true},
+		{"gke_my-project", cloudCluster{}, false},
+		{"minikube", cloudCluster{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseCloudCluster(tt.name)
+		if ok != tt.wantOk || got != tt.want {
+			t.Errorf("parseCloudCluster(%q)=%v,%v; expected=%v,%v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestListOp_autoSingle_warnCrossProject(t *testing.T) {
+	dir := testutil.TempHome(t)
+	testutil.TempKubeconfig(t, dir, testutil.KC().WithCurrentCtx("gke_proj-a_us-central1_c").
+		WithCtxs(testutil.Ctx("gke_proj-b_us-central1_c")))
+	defer testutil.WithEnvVar(env.EnvWarnCrossProject, "1")()
+
+	var stdout, stderr bytes.Buffer
+	if err := (ListOp{AutoSingle: true}).Run(&stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stderr.String(), `switched from GCP project "proj-a" to GCP project`) {
+		t.Errorf("no cross-project warning, stderr=%q", stderr.String())
+	}
+}
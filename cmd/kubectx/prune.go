@@ -0,0 +1,101 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// PruneOp indicates intention to remove the users and clusters that no
+// context refers to.
+type PruneOp struct {
+	DryRun bool // only print the entries that would be removed
+	Yes    bool // don't ask for confirmation
+}
+
+// parsePruneArgs parses the arguments following --prune.
+func parsePruneArgs(argv []string) Op {
+	var op PruneOp
+	for _, v := range argv {
+		switch v {
+		case "--dry-run":
+			op.DryRun = true
+		case "-y", "--yes":
+			op.Yes = true
+		default:
+			return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+		}
+	}
+	return op
+}
+
+func (op PruneOp) Run(stdout, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	users, clusters := kc.Orphans()
+	if len(users)+len(clusters) == 0 {
+		printer.Warning(stderr, "no unused users or clusters found")
+		return nil
+	}
+
+	if op.DryRun {
+		for _, u := range users {
+			fmt.Fprintf(stdout, "user %s\n", u)
+		}
+		for _, c := range clusters {
+			fmt.Fprintf(stdout, "cluster %s\n", c)
+		}
+		return nil
+	}
+	if !op.Yes {
+		fmt.Fprintln(stderr, "The following entries are not used by any context, and will be removed:")
+		for _, u := range users {
+			fmt.Fprintf(stderr, "  user %s\n", u)
+		}
+		for _, c := range clusters {
+			fmt.Fprintf(stderr, "  cluster %s\n", c)
+		}
+		ok, err := confirm(os.Stdin, stderr, "Remove them?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("prune cancelled")
+		}
+	}
+
+	for _, u := range users {
+		kc.DeleteUserEntry(u)
+	}
+	for _, c := range clusters {
+		kc.DeleteClusterEntry(c)
+	}
+	if err := kc.Save(); err != nil {
+		return errors.Wrap(err, "failed to save modified kubeconfig")
+	}
+	err := printer.Success(stderr, "Removed %d unused users and %d unused clusters.", len(users), len(clusters))
+	return errors.Wrap(err, "print error")
+}
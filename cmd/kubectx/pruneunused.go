@@ -0,0 +1,168 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// PruneUnusedOp indicates intention to delete the contexts that haven't
+// been used for a while.
+type PruneUnusedOp struct {
+	OlderThan time.Duration // delete the contexts not used within the duration
+	DryRun    bool          // only print the contexts that would be deleted
+	Yes       bool          // don't ask for confirmation
+
+	// IncludeNeverUsed also deletes the contexts without any history entry,
+	// which might just predate the history.
+	IncludeNeverUsed bool
+}
+
+// parsePruneUnusedArgs parses the arguments following --prune-unused.
+func parsePruneUnusedArgs(argv []string) Op {
+	var op PruneUnusedOp
+	for i := 0; i < len(argv); i++ {
+		switch v := argv[i]; v {
+		case "--older-than":
+			if i+1 >= len(argv) {
+				return UnsupportedOp{Err: fmt.Errorf("'%s' needs an argument", v)}
+			}
+			i++
+			d, err := parseAge(argv[i])
+			if err != nil {
+				return UnsupportedOp{Err: err}
+			}
+			op.OlderThan = d
+		case "--dry-run":
+			op.DryRun = true
+		case "-y", "--yes":
+			op.Yes = true
+		case "--include-never-used":
+			op.IncludeNeverUsed = true
+		default:
+			return UnsupportedOp{Err: fmt.Errorf("unsupported option '%s'", v)}
+		}
+	}
+	if op.OlderThan == 0 {
+		return UnsupportedOp{Err: fmt.Errorf("'--prune-unused' needs '--older-than <DURATION>'")}
+	}
+	return op
+}
+
+// parseAge parses a positive Go duration, or a number of days like "90d".
+func parseAge(v string) (time.Duration, error) {
+	if n, ok := strings.CutSuffix(v, "d"); ok {
+		days, err := strconv.Atoi(n)
+		if err != nil || days <= 0 {
+			return 0, errors.Errorf("invalid duration %q", v)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, errors.Errorf("invalid duration %q", v)
+	}
+	return d, nil
+}
+
+// formatAge formats the duration the way parseAge accepts it, in days if
+// it's a whole number of them.
+func formatAge(d time.Duration) string {
+	if day := 24 * time.Hour; d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
+
+// unusedContexts returns the contexts last used before the cutoff according
+// to the history entries, or never used if includeNeverUsed is set, with the
+// time they were last used. The current context and the locked ones are never
+// included.
+func unusedContexts(ctxs []string, entries []historyEntry, cur string, locked map[string]bool, cutoff time.Time,
+	includeNeverUsed bool) []contextLastUsed {
+	var out []contextLastUsed
+	for _, c := range lastUsedTimes(ctxs, entries) {
+		if c.Name == cur || locked[c.Name] {
+			continue
+		}
+		if (c.LastUsed == nil && includeNeverUsed) || (c.LastUsed != nil && c.LastUsed.Before(cutoff)) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (op PruneUnusedOp) Run(stdout, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	ctxs, cur := kc.ContextNames(), kc.GetCurrentContext()
+	kc.Close()
+
+	path, err := kubectxHistoryFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine history file")
+	}
+	entries, err := readHistory(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read history")
+	}
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	unused := unusedContexts(ctxs, entries, cur, lockSet(locks), now.Add(-op.OlderThan), op.IncludeNeverUsed)
+	if len(unused) == 0 {
+		printer.Warning(stderr, "no contexts unused for %s found", formatAge(op.OlderThan))
+		return nil
+	}
+
+	if op.DryRun {
+		return printLastUsed(stdout, unused, now)
+	}
+	if !op.Yes {
+		fmt.Fprintf(stderr, "The following contexts have not been used for %s, and will be deleted:\n", formatAge(op.OlderThan))
+		for _, c := range unused {
+			fmt.Fprintf(stderr, "  %s (last used: %s)\n", c.Name, formatAgo(c.LastUsed, now))
+		}
+		ok, err := confirm(os.Stdin, stderr, "Delete them?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("prune cancelled")
+		}
+	}
+
+	names := make([]string, len(unused))
+	for i, c := range unused {
+		names[i] = c.Name
+	}
+	return DeleteOp{Contexts: names}.Run(stdout, stderr)
+}
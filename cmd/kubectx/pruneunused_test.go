@@ -0,0 +1,82 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "90d", want: 90 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "0d", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "-5m", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseAge(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAge(%q) err=%v; wantErr=%v", tt.in, err, tt.wantErr)
+		} else if got != tt.want {
+			t.Errorf("parseAge(%q)=%v; want=%v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func Test_formatAge(t *testing.T) {
+	if got := formatAge(90 * 24 * time.Hour); got != "90d" {
+		t.Errorf("formatAge(90d)=%q", got)
+	}
+	if got := formatAge(36 * time.Hour); got != "36h0m0s" {
+		t.Errorf("formatAge(36h)=%q", got)
+	}
+}
+
+func Test_unusedContexts(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	entries := []historyEntry{
+		{Context: "recent", LastUsed: now.Add(-time.Hour)},
+		{Context: "old", LastUsed: now.Add(-100 * 24 * time.Hour)},
+		{Context: "old-current", LastUsed: now.Add(-100 * 24 * time.Hour)},
+		{Context: "old-locked", LastUsed: now.Add(-100 * 24 * time.Hour)},
+	}
+	ctxs := []string{"never", "old", "old-current", "old-locked", "recent"}
+	for _, tt := range []struct {
+		includeNeverUsed bool
+		want             []string
+	}{
+		{includeNeverUsed: false, want: []string{"old"}},
+		{includeNeverUsed: true, want: []string{"never", "old"}},
+	} {
+		got := unusedContexts(ctxs, entries, "old-current", map[string]bool{"old-locked": true},
+			now.Add(-90*24*time.Hour), tt.includeNeverUsed)
+
+		var names []string
+		for _, c := range got {
+			names = append(names, c.Name)
+		}
+		if diff := cmp.Diff(tt.want, names); diff != "" {
+			t.Errorf("unusedContexts(includeNeverUsed=%v) diff=%s", tt.includeNeverUsed, diff)
+		}
+	}
+}
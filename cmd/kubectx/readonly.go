@@ -0,0 +1,43 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// readOnly determines if the operation can run in read-only mode, as it
+// doesn't modify the kubeconfig or the state files. Operations are assumed
+// to modify them unless listed here.
+func readOnly(op Op) bool {
+	switch op := op.(type) {
+	case FileOutputOp:
+		return readOnly(op.Op)
+	case PruneOp:
+		return op.DryRun
+	case PruneUnusedOp:
+		return op.DryRun
+	case RenameRegexOp:
+		return op.DryRun
+	case RenameTemplateOp:
+		return op.DryRun
+	case RenameFromFileOp:
+		return op.DryRun
+	case NormalizeOp:
+		return !op.Apply
+	case UnsupportedOp, HelpOp, VersionOp, ListOp, CurrentOp, PeekOp, DescribeOp, WhereOp,
+		ResolveOp, RequireOp, ValidateNameOp, CompleteOp, CompletionsDirOp,
+		ListUsersOp, ListClustersOp, ListLocksOp, ListGroupsOp, StatsOp, HealthOp,
+		GraphOp, TreeOp, FishAbbrOp, WatchCurrentOp, ExecOp, WhichConfigOp:
+		return true
+	}
+	return false
+}
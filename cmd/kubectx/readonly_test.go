@@ -0,0 +1,93 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_readOnly(t *testing.T) {
+	tests := []struct {
+		op   Op
+		want bool
+	}{
+		{ListOp{}, true},
+		{ListOp{AutoSingle: true}, true},
+		{CurrentOp{}, true},
+		{FileOutputOp{Op: CurrentOp{}}, true},
+		{PruneOp{DryRun: true}, true},
+		{PruneOp{}, false},
+		{PruneUnusedOp{OlderThan: time.Hour, DryRun: true}, true},
+		{PruneUnusedOp{OlderThan: time.Hour}, false},
+		{NormalizeOp{}, true},
+		{NormalizeOp{Apply: true}, false},
+		{SwitchOp{Target: "a"}, false},
+		{InteractiveSwitchOp{}, false},
+		{RenameOp{Old: "a", New: "b"}, false},
+		{NoteOp{Context: "a"}, false},
+		{UndoOp{}, false},
+	}
+	for _, tt := range tests {
+		if got := readOnly(tt.op); got != tt.want {
+			t.Errorf("readOnly(%#v)=%v; want=%v", tt.op, got, tt.want)
+		}
+	}
+}
+
+func TestListOp_autoSingle_readOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctxs    []string
+		wantErr error
+		wantOut string
+	}{
+		{name: "single context is not switched to", ctxs: []string{"a"}, wantErr: cmdutil.ErrReadOnly},
+		{name: "many contexts are listed", ctxs: []string{"a", "b"}, wantOut: "a\nb\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer testutil.WithEnvVar(env.EnvReadOnly, "1")()
+			var ctxs []*testutil.Context
+			for _, c := range tt.ctxs {
+				ctxs = append(ctxs, testutil.Ctx(c))
+			}
+			kc := testutil.KC().WithCtxs(ctxs...)
+			path := testutil.TempKubeconfig(t, testutil.TempHome(t), kc)
+
+			var stdout, stderr bytes.Buffer
+			err := ListOp{AutoSingle: true}.Run(&stdout, &stderr)
+			if err != tt.wantErr {
+				t.Fatalf("err=%v; want=%v", err, tt.wantErr)
+			}
+			if got := stdout.String(); got != tt.wantOut {
+				t.Errorf("stdout=%q; want=%q", got, tt.wantOut)
+			}
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != kc.ToYAML(t) {
+				t.Errorf("kubeconfig was modified:\n%s", b)
+			}
+		})
+	}
+}
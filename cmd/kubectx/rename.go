@@ -15,19 +15,24 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/pkg/errors"
 
+	"github.com/ahmetb/kubectx/internal/cmdutil"
 	"github.com/ahmetb/kubectx/internal/kubeconfig"
 	"github.com/ahmetb/kubectx/internal/printer"
 )
 
 // RenameOp indicates intention to rename contexts.
 type RenameOp struct {
-	New string // NAME of New context
-	Old string // NAME of Old context (or '.' for current-context)
+	New   string // NAME of New context
+	Old   string // NAME of Old context (or '.' for current-context)
+	Force bool   // overwrite the New context even if it's locked
 }
 
 // parseRenameSyntax parses A=B form into [A,B] and returns
@@ -44,10 +49,98 @@ func parseRenameSyntax(v string) (string, string, bool) {
 	return new, old, true
 }
 
+// parseForcedRename parses the NEW=OLD form with "-f" or "--force" before or
+// after it.
+func parseForcedRename(argv []string) (Op, bool) {
+	if len(argv) != 2 {
+		return nil, false
+	}
+	for i, v := range argv {
+		if v != "-f" && v != "--force" {
+			continue
+		}
+		if new, old, ok := parseRenameSyntax(argv[1-i]); ok {
+			return RenameOp{New: new, Old: old, Force: true}, true
+		}
+	}
+	return nil, false
+}
+
+// validateName checks if kubectx can work with a context of the name.
+func validateName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("name can't be empty")
+	case name == "." || name == "-":
+		return errors.Errorf("\"%s\" is reserved by kubectx", name)
+	case strings.HasPrefix(name, "-"):
+		return errors.New("name can't start with '-'")
+	case strings.TrimSpace(name) != name:
+		return errors.New("name can't start or end with whitespace")
+	}
+	return nil
+}
+
+// validateNewName checks if the context old can be renamed to new, given
+// the existing context names.
+func validateNewName(names []string, old, new string) error {
+	if err := validateName(new); err != nil {
+		return err
+	}
+	if new == old {
+		return errors.New("new name is the same as the current name")
+	}
+	for _, n := range names {
+		if n == new {
+			return errors.Errorf("context \"%s\" already exists", new)
+		}
+	}
+	return nil
+}
+
+// promptNewName asks for a new name for the context old until a valid one
+// is entered. It returns "" if the user enters an empty name or the input
+// ends.
+func promptNewName(in io.Reader, out io.Writer, names []string, old string) (string, error) {
+	return promptName(bufio.NewScanner(in), out,
+		fmt.Sprintf("New name for context \"%s\" (empty to cancel): ", old),
+		func(new string) error { return validateNewName(names, old, new) })
+}
+
+// promptName asks the question until the answer is empty or passes validate,
+// and returns the answer. It returns "" if the input ends.
+func promptName(s *bufio.Scanner, out io.Writer, question string, validate func(string) error) (string, error) {
+	for {
+		if _, err := fmt.Fprint(out, question); err != nil {
+			return "", errors.Wrap(err, "write error")
+		}
+		if !s.Scan() {
+			fmt.Fprintln(out)
+			return "", errors.Wrap(s.Err(), "failed to read new name")
+		}
+		new := strings.TrimRight(s.Text(), "\r")
+		if new == "" {
+			return "", nil
+		}
+		if err := validate(new); err != nil {
+			printer.Error(out, "%v", err)
+			continue
+		}
+		return new, nil
+	}
+}
+
 // rename changes the old (NAME or '.' for current-context)
 // to the "new" value. If the old refers to the current-context,
 // current-context preference is also updated.
 func (op RenameOp) Run(_, stderr io.Writer) error {
+	// the reserved names are only special as the new name: "." as the old
+	// name means the current context, and "-" is a context named "-", which
+	// can be renamed to make it switchable
+	if err := validateName(op.New); err != nil {
+		return errors.Wrapf(err, "can't rename context to \"%s\"", op.New)
+	}
+
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
 	defer kc.Close()
 	if err := kc.Parse(); err != nil {
@@ -56,6 +149,9 @@ func (op RenameOp) Run(_, stderr io.Writer) error {
 
 	cur := kc.GetCurrentContext()
 	if op.Old == "." {
+		if cur == "" {
+			return errors.New("current-context is not set, can't rename '.'")
+		}
 		op.Old = cur
 	}
 
@@ -63,8 +159,37 @@ func (op RenameOp) Run(_, stderr io.Writer) error {
 		return errors.Errorf("context \"%s\" not found, can't rename it", op.Old)
 	}
 
+	if kc.ContextExists(op.New) && op.New != op.Old && cmdutil.CanPrompt() {
+		names := kc.ContextNames()
+		fmt.Fprintf(stderr, "Context %s\n", contextDetails(kc, op.New))
+		alt, err := promptName(bufio.NewScanner(os.Stdin), stderr,
+			fmt.Sprintf("New name for context \"%s\" (empty to overwrite \"%s\"): ", op.Old, op.New),
+			func(new string) error { return validateNewName(names, op.Old, new) })
+		if err != nil {
+			return err
+		}
+		if alt != "" {
+			op.New = alt
+		}
+	}
+
+	undo := undoEntry{Renames: []renamePair{{Old: op.Old, New: op.New}}}
 	if kc.ContextExists(op.New) {
+		locks, err := readLocks()
+		if err != nil {
+			return err
+		}
+		locked := lockSet(locks)[op.New]
+		if locked && !op.Force {
+			return errors.Errorf("context \"%s\" is locked, can't overwrite it (use \"%s --unlock %s\" or \"--force\")",
+				op.New, selfName(), op.New)
+		}
 		printer.Warning(stderr, "context \"%s\" exists, overwriting it.", op.New)
+		entry, err := kc.ContextEntry(op.New)
+		if err != nil {
+			return errors.Wrap(err, "failed to read new context to overwrite it")
+		}
+		undo.Deleted = append(undo.Deleted, deletedContext{Name: op.New, Entry: string(entry), Locked: locked})
 		if err := kc.DeleteContextEntry(op.New); err != nil {
 			return errors.Wrap(err, "failed to delete new context to overwrite it")
 		}
@@ -81,8 +206,25 @@ func (op RenameOp) Run(_, stderr io.Writer) error {
 	if err := kc.Save(); err != nil {
 		return errors.Wrap(err, "failed to save modified kubeconfig")
 	}
-	printer.Success(stderr, "Context %s renamed to %s.",
-		printer.SuccessColor.Sprint(op.Old),
-		printer.SuccessColor.Sprint(op.New))
+	if err := recordUndo(undo); err != nil {
+		return err
+	}
+	if err := renameStateReferences(undo.Renames); err != nil {
+		return errors.Wrap(err, "failed to update state files with the new name")
+	}
+	printRenamed(stderr, op.Old, op.New)
+	for _, d := range undo.Deleted {
+		audit(stderr, auditEntry{Operation: auditDelete, Target: d.Name})
+		runDeleteHook(stderr, d.Name)
+	}
+	audit(stderr, auditEntry{Operation: auditRename, Target: op.Old, NewName: op.New})
+	runRenameHook(stderr, op.Old, op.New)
 	return nil
 }
+
+// printRenamed prints a before and after message for a renamed context.
+func printRenamed(w io.Writer, old, new string) error {
+	return printer.Success(w, "Renamed context \"%s\" to \"%s\".",
+		printer.RemovedColor.Sprint(old),
+		printer.SuccessColor.Sprint(new))
+}
@@ -15,9 +15,18 @@
 package main
 
 import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/testutil"
 )
 
 func Test_parseRenameSyntax(t *testing.T) {
@@ -81,3 +90,253 @@ func Test_parseRenameSyntax(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateNewName(t *testing.T) {
+	names := []string{"a", "b"}
+	tests := []struct {
+		new     string
+		wantErr bool
+	}{
+		{new: "c"},
+		{new: "", wantErr: true},
+		{new: "a", wantErr: true},
+		{new: "b", wantErr: true},
+		{new: ".", wantErr: true},
+		{new: "-", wantErr: true},
+		{new: "-c", wantErr: true},
+		{new: " c", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateNewName(names, "a", tt.new)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateNewName(%q) err=%v, wantErr=%v", tt.new, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_promptNewName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "valid name", in: "c\n", want: "c"},
+		{name: "reprompts until valid", in: "b\n.\nc\n", want: "c"},
+		{name: "empty cancels", in: "b\n\nc\n", want: ""},
+		{name: "end of input cancels", in: "b\n", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := promptNewName(strings.NewReader(tt.in), &out, []string{"a", "b"}, "a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got=%q, want=%q (output: %s)", got, tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestRenameOp_followsReferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          RenameOp
+		wantCurrent string
+		wantPrev    string
+		wantHistory []string
+	}{
+		{name: "current context", op: RenameOp{Old: ".", New: "c"},
+			wantCurrent: "c", wantPrev: "b", wantHistory: []string{"c", "b"}},
+		{name: "previous context", op: RenameOp{Old: "b", New: "c"},
+			wantCurrent: "a", wantPrev: "c", wantHistory: []string{"a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testutil.TempHome(t)
+			testutil.TempKubeconfig(t, dir, testutil.KC().WithCurrentCtx("a").WithCtxs(testutil.Ctx("a"), testutil.Ctx("b")))
+
+			prevFile, err := kubectxPrevCtxFile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := writeLastContext(prevFile, "b"); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range []string{"b", "a"} {
+				if err := recordContextUse(c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := tt.op.Run(ioutil.Discard, ioutil.Discard); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+			defer got.Close()
+			if err := got.Parse(); err != nil {
+				t.Fatal(err)
+			}
+			if v := got.GetCurrentContext(); v != tt.wantCurrent {
+				t.Errorf("current-context=%q; want=%q", v, tt.wantCurrent)
+			}
+			prev, err := readLastContext(prevFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prev != tt.wantPrev {
+				t.Errorf("previous context=%q; want=%q", prev, tt.wantPrev)
+			}
+			historyFile, err := kubectxHistoryFile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			history, err := readHistory(historyFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, e := range history {
+				names = append(names, e.Context)
+			}
+			if diff := cmp.Diff(tt.wantHistory, names); diff != "" {
+				t.Errorf("history diff=%s", diff)
+			}
+		})
+	}
+}
+
+func TestRenameOp_hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	dir := testutil.TempHome(t)
+	testutil.TempKubeconfig(t, dir, testutil.KC().WithCurrentCtx("a").WithCtxs(testutil.Ctx("a"), testutil.Ctx("b")))
+	deleteHook := filepath.Join(dir, "delete-hook")
+	if err := ioutil.WriteFile(deleteHook, []byte("#!/bin/sh\necho deleted \"$@\"\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer testutil.WithEnvVar(env.EnvPostRenameHook, "echo renamed")()
+	defer testutil.WithEnvVar(env.EnvPostDeleteHook, deleteHook)()
+
+	// renaming onto "b" deletes it, then renames "a"; the failing delete hook
+	// only warns
+	var stderr bytes.Buffer
+	if err := (RenameOp{Old: "a", New: "b"}).Run(ioutil.Discard, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	out := stderr.String()
+	for _, want := range []string{"deleted b\n", env.EnvPostDeleteHook + " failed", "renamed a b\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stderr=%q; want it to contain %q", out, want)
+		}
+	}
+	if strings.Index(out, "deleted b") > strings.Index(out, "renamed a b") {
+		t.Errorf("delete hook ran after the rename hook: %q", out)
+	}
+}
+
+func TestRenameOp_lockedNewName(t *testing.T) {
+	dir := testutil.TempHome(t)
+	testutil.TempKubeconfig(t, dir, testutil.KC().WithCurrentCtx("a").WithCtxs(testutil.Ctx("a"), testutil.Ctx("b")))
+	if err := writeLocks([]string{"b"}); err != nil {
+		t.Fatal(err)
+	}
+	names := func() []string {
+		t.Helper()
+		got := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+		defer got.Close()
+		if err := got.Parse(); err != nil {
+			t.Fatal(err)
+		}
+		return got.ContextNames()
+	}
+	checkLocks := func(want []string) {
+		t.Helper()
+		locks, err := readLocks()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, locks); diff != "" {
+			t.Errorf("locks diff=%s", diff)
+		}
+	}
+
+	if err := (RenameOp{Old: "a", New: "b"}).Run(ioutil.Discard, ioutil.Discard); err == nil ||
+		!strings.Contains(err.Error(), "is locked") {
+		t.Fatalf("err=%v; want locked error", err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, names()); diff != "" {
+		t.Errorf("context names diff=%s", diff)
+	}
+	checkLocks([]string{"b"})
+
+	if err := (RenameOp{Old: "a", New: "b", Force: true}).Run(ioutil.Discard, ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"b"}, names()); diff != "" {
+		t.Errorf("context names diff=%s", diff)
+	}
+	checkLocks(nil)
+
+	if err := (UndoOp{}).Run(ioutil.Discard, ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, names()); diff != "" {
+		t.Errorf("context names after undo diff=%s", diff)
+	}
+	checkLocks([]string{"b"})
+}
+
+func TestRenameOp_reservedNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		op        RenameOp
+		current   string
+		wantErr   string
+		wantNames []string
+	}{
+		{name: "to '-'", op: RenameOp{Old: "a", New: "-"}, current: "a",
+			wantErr: `can't rename context to "-": "-" is reserved by kubectx`},
+		{name: "to '.'", op: RenameOp{Old: "a", New: "."}, current: "a",
+			wantErr: `can't rename context to ".": "." is reserved by kubectx`},
+		{name: "to empty", op: RenameOp{Old: "a", New: ""}, current: "a",
+			wantErr: `can't rename context to "": name can't be empty`},
+		{name: "from '.' meaning current", op: RenameOp{Old: ".", New: "b"}, current: "a",
+			wantNames: []string{"b", "-"}},
+		{name: "from '.' without current", op: RenameOp{Old: ".", New: "b"},
+			wantErr: "current-context is not set, can't rename '.'"},
+		{name: "from literal '-'", op: RenameOp{Old: "-", New: "b"}, current: "a",
+			wantNames: []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := testutil.KC().WithCtxs(testutil.Ctx("a"), testutil.Ctx("-"))
+			if tt.current != "" {
+				kc = kc.WithCurrentCtx(tt.current)
+			}
+			testutil.TempKubeconfig(t, testutil.TempHome(t), kc)
+
+			err := tt.op.Run(ioutil.Discard, ioutil.Discard)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err=%v; want=%q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+			defer got.Close()
+			if err := got.Parse(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantNames, got.ContextNames()); diff != "" {
+				t.Errorf("context names diff=%s", diff)
+			}
+		})
+	}
+}
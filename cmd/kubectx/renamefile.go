@@ -0,0 +1,148 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// RenameFromFileOp indicates intention to rename the contexts following a
+// mapping file of "old=new" lines.
+type RenameFromFileOp struct {
+	File   string // path of the mapping file, or "-" for stdin
+	DryRun bool   // only print the renames that would be done
+}
+
+// parseRenameFromFileArgs parses the arguments following --rename-from-file.
+func parseRenameFromFileArgs(argv []string) Op {
+	var op RenameFromFileOp
+	var positional []string
+	for _, v := range argv {
+		if v == "--dry-run" {
+			op.DryRun = true
+			continue
+		}
+		positional = append(positional, v)
+	}
+	if len(positional) != 1 || positional[0] == "" {
+		return UnsupportedOp{Err: fmt.Errorf("'--rename-from-file' needs a mapping file")}
+	}
+	op.File = positional[0]
+	return op
+}
+
+// readRenameMapping parses the "old=new" lines of a mapping file, in their
+// order. Empty lines and lines starting with '#' are ignored.
+func readRenameMapping(r io.Reader) ([]renamePair, error) {
+	var out []renamePair
+	seen := make(map[string]int)
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		old, new, ok := strings.Cut(line, "=")
+		old, new = strings.TrimSpace(old), strings.TrimSpace(new)
+		if !ok || old == "" {
+			return nil, errors.Errorf("line %d: expected \"old=new\", got %q", n, line)
+		}
+		if err := validateName(new); err != nil {
+			return nil, errors.Wrapf(err, "line %d: invalid new name %q", n, new)
+		}
+		if prev, ok := seen[old]; ok {
+			return nil, errors.Errorf("line %d: context \"%s\" is already renamed on line %d", n, old, prev)
+		}
+		seen[old] = n
+		out = append(out, renamePair{Old: old, New: new})
+	}
+	return out, errors.Wrap(s.Err(), "failed to read mapping file")
+}
+
+// renameMappingPlan checks the mapping against the existing context names and
+// returns the renames to be made, failing without returning a plan if a
+// context doesn't exist or any new name collides.
+func renameMappingPlan(names []string, mapping []renamePair) ([]renamePair, error) {
+	existing := make(map[string]bool, len(names))
+	for _, n := range names {
+		existing[n] = true
+	}
+	targets := make([]string, 0, len(mapping))
+	newNames := make(map[string]string, len(mapping))
+	var missing []string
+	for _, p := range mapping {
+		if !existing[p.Old] {
+			missing = append(missing, p.Old)
+		}
+		targets = append(targets, p.Old)
+		newNames[p.Old] = p.New
+	}
+	if len(missing) > 0 {
+		return nil, errors.Errorf("contexts not found: %s", quoteJoin(missing))
+	}
+	return renamePlan(names, targets, func(old string) (string, error) { return newNames[old], nil })
+}
+
+func (op RenameFromFileOp) Run(stdout, stderr io.Writer) error {
+	in := io.Reader(os.Stdin)
+	if op.File != "-" {
+		f, err := os.Open(op.File)
+		if err != nil {
+			return errors.Wrap(err, "failed to open mapping file")
+		}
+		defer f.Close()
+		in = f
+	}
+	mapping, err := readRenameMapping(in)
+	if err != nil {
+		return err
+	}
+
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	plan, err := renameMappingPlan(kc.ContextNames(), mapping)
+	if err != nil {
+		return errors.Wrap(err, "no contexts were renamed")
+	}
+	if len(plan) == 0 {
+		printer.Warning(stderr, "the mapping renames no contexts")
+		return nil
+	}
+
+	if op.DryRun {
+		for _, p := range plan {
+			fmt.Fprintf(stdout, "%s -> %s\n", p.Old, p.New)
+		}
+		return nil
+	}
+	if err := applyRenames(stderr, kc, plan); err != nil {
+		return err
+	}
+	return printer.Success(stderr, "Renamed %d of %d contexts in the mapping (%d unchanged).",
+		len(plan), len(mapping), len(mapping)-len(plan))
+}
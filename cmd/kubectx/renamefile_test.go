@@ -0,0 +1,72 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_readRenameMapping(t *testing.T) {
+	got, err := readRenameMapping(strings.NewReader("# provisioned clusters\n\n a = b \nc=d\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]renamePair{{"a", "b"}, {"c", "d"}}, got); diff != "" {
+		t.Fatalf("diff=%s", diff)
+	}
+
+	for _, in := range []string{"a\n", "=b\n", "a=\n", "a=-b\n", "a=b\na=c\n"} {
+		if _, err := readRenameMapping(strings.NewReader(in)); err == nil {
+			t.Errorf("expected error for %q", in)
+		}
+	}
+}
+
+func Test_renameMappingPlan(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	tests := []struct {
+		name    string
+		mapping []renamePair
+		want    []renamePair
+		wantErr bool
+	}{
+		{name: "renames",
+			mapping: []renamePair{{"a", "x"}, {"b", "b"}, {"c", "y"}},
+			want:    []renamePair{{"a", "x"}, {"c", "y"}}},
+		{name: "missing context",
+			mapping: []renamePair{{"a", "x"}, {"z", "y"}},
+			wantErr: true},
+		{name: "collision with existing context",
+			mapping: []renamePair{{"a", "x"}, {"b", "c"}},
+			wantErr: true},
+		{name: "collision within mapping",
+			mapping: []renamePair{{"a", "x"}, {"b", "x"}},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renameMappingPlan(names, tt.mapping)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("diff=%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// RenameRegexOp indicates intention to rename all contexts matching a
+// regular expression.
+type RenameRegexOp struct {
+	Pattern     string // regular expression in Go regexp syntax
+	Replacement string // replacement, may contain $1-style references
+	DryRun      bool   // only print the renames that would be done
+}
+
+// renamePair describes a single context rename.
+type renamePair struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// parseRenameRegexArgs parses the arguments following --rename-regex.
+func parseRenameRegexArgs(argv []string) Op {
+	var op RenameRegexOp
+	var positional []string
+	for _, v := range argv {
+		if v == "--dry-run" {
+			op.DryRun = true
+			continue
+		}
+		positional = append(positional, v)
+	}
+	if len(positional) != 2 {
+		return UnsupportedOp{Err: fmt.Errorf("'--rename-regex' needs a pattern and a replacement")}
+	}
+	op.Pattern, op.Replacement = positional[0], positional[1]
+	return op
+}
+
+// renameRegexPlan applies re to each of the context names and returns the
+// renames to be made, in the order of names. It fails without returning
+// a plan if any new name is empty, or collides with another new name or an
+// existing context.
+func renameRegexPlan(names []string, re *regexp.Regexp, repl string) ([]renamePair, error) {
+	return renamePlan(names, names, func(old string) (string, error) {
+		if !re.MatchString(old) {
+			return old, nil
+		}
+		return re.ReplaceAllString(old, repl), nil
+	})
+}
+
+// renamePlan returns the renames of the contexts in targets to the names
+// returned by newName, in the order of targets. Contexts whose name doesn't
+// change are skipped. It fails without returning a plan if any new name is
+// invalid, or collides with another new name or an existing context in names.
+func renamePlan(names, targets []string, newName func(string) (string, error)) ([]renamePair, error) {
+	existing := make(map[string]bool, len(names))
+	for _, n := range names {
+		existing[n] = true
+	}
+
+	var plan []renamePair
+	sources := make(map[string][]string)
+	for _, old := range targets {
+		new, err := newName(old)
+		if err != nil {
+			return nil, err
+		}
+		if new == old {
+			continue
+		}
+		if err := validateName(new); err != nil {
+			return nil, errors.Wrapf(err, "context \"%s\" can't be renamed to \"%s\"", old, new)
+		}
+		plan = append(plan, renamePair{Old: old, New: new})
+		sources[new] = append(sources[new], old)
+	}
+
+	var collisions []string
+	for _, p := range plan {
+		from := sources[p.New]
+		if from == nil {
+			continue // already reported
+		}
+		if existing[p.New] {
+			collisions = append(collisions, fmt.Sprintf("\"%s\" (from %s) already exists",
+				p.New, quoteJoin(from)))
+		} else if len(from) > 1 {
+			collisions = append(collisions, fmt.Sprintf("\"%s\" (from %s)",
+				p.New, quoteJoin(from)))
+		}
+		delete(sources, p.New)
+	}
+	if len(collisions) > 0 {
+		return nil, collisionError{plan: plan, collisions: collisions}
+	}
+	return plan, nil
+}
+
+// collisionError is returned by renamePlan with the plan causing the
+// collisions, so they can be resolved interactively.
+type collisionError struct {
+	plan       []renamePair
+	collisions []string
+}
+
+func (e collisionError) Error() string {
+	return "renames would cause name collisions: " + strings.Join(e.collisions, ", ")
+}
+
+func quoteJoin(v []string) string {
+	out := make([]string, len(v))
+	for i, s := range v {
+		out[i] = fmt.Sprintf("\"%s\"", s)
+	}
+	return strings.Join(out, ", ")
+}
+
+func (op RenameRegexOp) Run(stdout, stderr io.Writer) error {
+	re, err := regexp.Compile(op.Pattern)
+	if err != nil {
+		return errors.Wrap(err, "invalid rename pattern")
+	}
+
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	plan, err := renameRegexPlan(kc.ContextNames(), re, op.Replacement)
+	if err != nil {
+		if plan, err = resolveInteractively(stderr, kc, err, op.DryRun); err != nil {
+			return err
+		}
+	}
+	if len(plan) == 0 {
+		printer.Warning(stderr, "no context names match \"%s\"", op.Pattern)
+		return nil
+	}
+
+	if op.DryRun {
+		for _, p := range plan {
+			fmt.Fprintf(stdout, "%s -> %s\n", p.Old, p.New)
+		}
+		return nil
+	}
+
+	return applyRenames(stderr, kc, plan)
+}
+
+// applyRenames renames the contexts following the plan, along with their
+// references in current-context and the state files, and records the renames
+// for "kubectx --undo" to revert them all at once.
+func applyRenames(stderr io.Writer, kc *kubeconfig.Kubeconfig, plan []renamePair) error {
+	cur := kc.GetCurrentContext()
+	for _, p := range plan {
+		if err := kc.ModifyContextName(p.Old, p.New); err != nil {
+			return errors.Wrapf(err, "failed to change context name \"%s\"", p.Old)
+		}
+		if p.Old == cur {
+			if err := kc.ModifyCurrentContext(p.New); err != nil {
+				return errors.Wrap(err, "failed to set current-context to new name")
+			}
+		}
+	}
+	if err := kc.Save(); err != nil {
+		return errors.Wrap(err, "failed to save modified kubeconfig")
+	}
+	// record the whole batch as soon as the kubeconfig is saved, so it can be
+	// undone even if updating the state files fails
+	if err := recordUndo(undoEntry{Renames: plan}); err != nil {
+		return err
+	}
+	if err := renameStateReferences(plan); err != nil {
+		return errors.Wrap(err, "failed to update state files with the new names")
+	}
+	for _, p := range plan {
+		printRenamed(stderr, p.Old, p.New)
+		audit(stderr, auditEntry{Operation: auditRename, Target: p.Old, NewName: p.New})
+		runRenameHook(stderr, p.Old, p.New)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_renameRegexPlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		pattern string
+		repl    string
+		want    []renamePair
+		wantErr bool
+	}{
+		{
+			name:    "strip prefix",
+			names:   []string{"gke_project_a", "gke_project_b", "minikube"},
+			pattern: "^gke_project_",
+			repl:    "",
+			want: []renamePair{
+				{Old: "gke_project_a", New: "a"},
+				{Old: "gke_project_b", New: "b"},
+			},
+		},
+		{
+			name:    "capture groups",
+			names:   []string{"user@cluster1", "user@cluster2"},
+			pattern: `^user@(.*)$`,
+			repl:    "${1}-admin",
+			want: []renamePair{
+				{Old: "user@cluster1", New: "cluster1-admin"},
+				{Old: "user@cluster2", New: "cluster2-admin"},
+			},
+		},
+		{
+			name:    "no matches",
+			names:   []string{"a", "b"},
+			pattern: "^x",
+			repl:    "y",
+			want:    nil,
+		},
+		{
+			name:    "collides with existing context",
+			names:   []string{"prefix-a", "a"},
+			pattern: "^prefix-",
+			repl:    "",
+			wantErr: true,
+		},
+		{
+			name:    "collides with another new name",
+			names:   []string{"x-a", "y-a"},
+			pattern: "^[xy]-",
+			repl:    "",
+			wantErr: true,
+		},
+		{
+			name:    "empty new name",
+			names:   []string{"abc"},
+			pattern: ".*",
+			repl:    "",
+			wantErr: true,
+		},
+		{
+			name:    "reserved new name",
+			names:   []string{"a", "b"},
+			pattern: ".*",
+			repl:    "-",
+			wantErr: true,
+		},
+		{
+			name:    "new name starting with dash",
+			names:   []string{"prod-a"},
+			pattern: "^prod",
+			repl:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renameRegexPlan(tt.names, regexp.MustCompile(tt.pattern), tt.repl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renameRegexPlan() err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("renameRegexPlan() diff=%s", diff)
+			}
+		})
+	}
+}
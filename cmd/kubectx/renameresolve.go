@@ -0,0 +1,99 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// resolveInteractively returns the plan of a collisionError with the
+// collisions resolved by asking for other names, if the user can be asked.
+// Otherwise, or for dry runs, it returns err.
+func resolveInteractively(stderr io.Writer, kc *kubeconfig.Kubeconfig, err error, dryRun bool) ([]renamePair, error) {
+	ce, ok := err.(collisionError)
+	if !ok || dryRun || !cmdutil.CanPrompt() {
+		return nil, err
+	}
+	printer.Warning(stderr, "%v", err)
+	return resolveCollisions(os.Stdin, stderr, kc.ContextNames(), ce.plan,
+		func(name string) string { return contextDetails(kc, name) })
+}
+
+// resolveCollisions asks for another new name for each rename in the plan
+// colliding with an existing context or an earlier rename, after printing
+// the details of the existing context. Renames the user enters no name for
+// are dropped from the returned plan.
+func resolveCollisions(in io.Reader, out io.Writer, names []string, plan []renamePair, details func(string) string) ([]renamePair, error) {
+	taken := make(map[string]string, len(names)+len(plan)) // new name -> context it was taken by
+	for _, n := range names {
+		taken[n] = n
+	}
+	s := bufio.NewScanner(in)
+	var resolved []renamePair
+	for _, p := range plan {
+		by, collides := taken[p.New]
+		if !collides {
+			taken[p.New] = p.Old
+			resolved = append(resolved, p)
+			continue
+		}
+		if by == p.New {
+			fmt.Fprintf(out, "Context %s\n", details(p.New))
+		} else {
+			fmt.Fprintf(out, "Context \"%s\" is also being renamed to \"%s\".\n", by, p.New)
+		}
+		new, err := promptName(s, out,
+			fmt.Sprintf("New name for context \"%s\" instead of \"%s\" (empty to skip it): ", p.Old, p.New),
+			func(new string) error {
+				if err := validateName(new); err != nil {
+					return err
+				}
+				if _, ok := taken[new]; ok && new != p.Old {
+					return errors.Errorf("context \"%s\" already exists or is a new name", new)
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		if new == "" || new == p.Old {
+			printer.Warning(out, "not renaming context \"%s\"", p.Old)
+			continue
+		}
+		taken[new] = p.Old
+		resolved = append(resolved, renamePair{Old: p.Old, New: new})
+	}
+	return resolved, nil
+}
+
+// contextDetails describes the context on a single line, to help choosing
+// between it and another context of the same name.
+func contextDetails(kc *kubeconfig.Kubeconfig, name string) string {
+	cluster, _ := kc.ClusterOfContext(name)
+	user, _ := kc.UserOfContext(name)
+	ns, _ := kc.NamespaceOfContext(name)
+	path, _ := kc.ContextSource(name)
+	return fmt.Sprintf("\"%s\" already exists (cluster: %s, user: %s, namespace: %s, file: %s).",
+		name, cluster, user, ns, path)
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_resolveCollisions(t *testing.T) {
+	names := []string{"prefix-a", "x-b", "y-b", "a"}
+	plan := []renamePair{
+		{Old: "prefix-a", New: "a"},
+		{Old: "x-b", New: "b"},
+		{Old: "y-b", New: "b"},
+	}
+	var detailed []string
+	details := func(name string) string {
+		detailed = append(detailed, name)
+		return name
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want []renamePair
+	}{
+		{
+			name: "new names",
+			in:   "a2\nb2\n",
+			want: []renamePair{
+				{Old: "prefix-a", New: "a2"},
+				{Old: "x-b", New: "b"},
+				{Old: "y-b", New: "b2"},
+			},
+		},
+		{
+			name: "reprompts for taken names",
+			in:   "a\n-c\na2\nb\nx-b\nb2\n",
+			want: []renamePair{
+				{Old: "prefix-a", New: "a2"},
+				{Old: "x-b", New: "b"},
+				{Old: "y-b", New: "b2"},
+			},
+		},
+		{
+			name: "empty skips",
+			in:   "\n\n",
+			want: []renamePair{{Old: "x-b", New: "b"}},
+		},
+		{
+			name: "end of input skips",
+			in:   "",
+			want: []renamePair{{Old: "x-b", New: "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detailed = nil
+			var out bytes.Buffer
+			got, err := resolveCollisions(strings.NewReader(tt.in), &out, names, plan, details)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("diff=%s (output: %s)", diff, out.String())
+			}
+			if diff := cmp.Diff([]string{"a"}, detailed); diff != "" {
+				t.Errorf("expected details of the existing context only, diff=%s", diff)
+			}
+		})
+	}
+}
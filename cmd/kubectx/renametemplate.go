@@ -0,0 +1,147 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// RenameTemplateOp indicates intention to rename contexts to the names
+// produced by a template.
+type RenameTemplateOp struct {
+	Template string   // text/template executed with renameTemplateData
+	Contexts []string // contexts to rename ("." for current-context), or nil for all
+	DryRun   bool     // only print the renames that would be done
+}
+
+// renameTemplateData is the data rename templates are executed with.
+type renameTemplateData struct {
+	Name      string // the current name of the context
+	Cluster   string
+	User      string
+	Namespace string
+}
+
+// parseRenameTemplateArgs parses the arguments following --rename-template.
+func parseRenameTemplateArgs(argv []string) Op {
+	var op RenameTemplateOp
+	var positional []string
+	for _, v := range argv {
+		if v == "--dry-run" {
+			op.DryRun = true
+			continue
+		}
+		positional = append(positional, v)
+	}
+	if len(positional) == 0 {
+		return UnsupportedOp{Err: fmt.Errorf("'--rename-template' needs a template")}
+	}
+	op.Template = positional[0]
+	if len(positional) > 1 {
+		op.Contexts = positional[1:]
+	}
+	return op
+}
+
+// templateDataOf returns the data to execute rename templates with for the
+// context.
+func templateDataOf(kc *kubeconfig.Kubeconfig, ctx string) (renameTemplateData, error) {
+	d := renameTemplateData{Name: ctx}
+	var err error
+	if d.Cluster, err = kc.ClusterOfContext(ctx); err != nil {
+		return d, err
+	}
+	if d.User, err = kc.UserOfContext(ctx); err != nil {
+		return d, err
+	}
+	d.Namespace, err = kc.NamespaceOfContext(ctx)
+	return d, err
+}
+
+// renameTemplatePlan executes tmpl for each of the targets and returns the
+// renames to be made, with the same guards as renameRegexPlan.
+func renameTemplatePlan(names, targets []string, tmpl *template.Template,
+	data func(string) (renameTemplateData, error)) ([]renamePair, error) {
+	return renamePlan(names, targets, func(old string) (string, error) {
+		d, err := data(old)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read context \"%s\"", old)
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, d); err != nil {
+			return "", errors.Wrapf(err, "failed to execute template for context \"%s\"", old)
+		}
+		return strings.TrimSpace(b.String()), nil
+	})
+}
+
+func (op RenameTemplateOp) Run(stdout, stderr io.Writer) error {
+	tmpl, err := template.New("rename").Parse(op.Template)
+	if err != nil {
+		return errors.Wrap(err, "invalid rename template")
+	}
+
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	names := kc.ContextNames()
+	targets := names
+	if len(op.Contexts) > 0 {
+		targets = nil
+		for _, v := range op.Contexts {
+			if v == "." {
+				v = kc.GetCurrentContext()
+			}
+			ctx, err := resolveContext(names, v, true)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, ctx)
+		}
+	}
+
+	plan, err := renameTemplatePlan(names, targets, tmpl, func(ctx string) (renameTemplateData, error) {
+		return templateDataOf(kc, ctx)
+	})
+	if err != nil {
+		if plan, err = resolveInteractively(stderr, kc, err, op.DryRun); err != nil {
+			return err
+		}
+	}
+	if len(plan) == 0 {
+		printer.Warning(stderr, "no context names would change")
+		return nil
+	}
+
+	if op.DryRun {
+		for _, p := range plan {
+			fmt.Fprintf(stdout, "%s -> %s\n", p.Old, p.New)
+		}
+		return nil
+	}
+	return applyRenames(stderr, kc, plan)
+}
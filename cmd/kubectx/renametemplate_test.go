@@ -0,0 +1,107 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_renameTemplatePlan(t *testing.T) {
+	data := map[string]renameTemplateData{
+		"ctx1":  {Name: "ctx1", Cluster: "prod", User: "admin", Namespace: "web"},
+		"ctx2":  {Name: "ctx2", Cluster: "dev", User: "admin", Namespace: "web"},
+		"ctx3":  {Name: "ctx3", Cluster: "prod", User: "admin", Namespace: "web"},
+		"ok":    {Name: "ok", Cluster: "ok", Namespace: "default"},
+		"empty": {Name: "empty"},
+	}
+	lookup := func(ctx string) (renameTemplateData, error) { return data[ctx], nil }
+
+	tests := []struct {
+		name    string
+		names   []string
+		targets []string
+		tmpl    string
+		want    []renamePair
+		wantErr bool
+	}{
+		{
+			name:    "cluster and namespace",
+			names:   []string{"ctx1", "ctx2"},
+			targets: []string{"ctx1", "ctx2"},
+			tmpl:    "{{.Cluster}}-{{.Namespace}}",
+			want: []renamePair{
+				{Old: "ctx1", New: "prod-web"},
+				{Old: "ctx2", New: "dev-web"},
+			},
+		},
+		{
+			name:    "only targets",
+			names:   []string{"ctx1", "ctx2", "ctx3"},
+			targets: []string{"ctx2"},
+			tmpl:    "{{.Cluster}}",
+			want:    []renamePair{{Old: "ctx2", New: "dev"}},
+		},
+		{
+			name:    "unchanged names are skipped",
+			names:   []string{"ok", "ctx2"},
+			targets: []string{"ok", "ctx2"},
+			tmpl:    "{{.Cluster}}",
+			want:    []renamePair{{Old: "ctx2", New: "dev"}},
+		},
+		{
+			name:    "collides with another new name",
+			names:   []string{"ctx1", "ctx3"},
+			targets: []string{"ctx1", "ctx3"},
+			tmpl:    "{{.Cluster}}",
+			wantErr: true,
+		},
+		{
+			name:    "collides with existing context",
+			names:   []string{"ctx1", "prod"},
+			targets: []string{"ctx1"},
+			tmpl:    "{{.Cluster}}",
+			wantErr: true,
+		},
+		{
+			name:    "empty new name",
+			names:   []string{"empty"},
+			targets: []string{"empty"},
+			tmpl:    "{{.Cluster}}",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			names:   []string{"ctx1"},
+			targets: []string{"ctx1"},
+			tmpl:    "{{.Region}}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("").Parse(tt.tmpl))
+			got, err := renameTemplatePlan(tt.names, tt.targets, tmpl, lookup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("diff=%s", diff)
+			}
+		})
+	}
+}
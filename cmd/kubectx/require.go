@@ -0,0 +1,83 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// RequireOp fails unless the current context (and optionally its namespace)
+// match the glob patterns, without switching.
+type RequireOp struct {
+	Context   string // glob pattern of the context name
+	Namespace string // glob pattern of the namespace, or "" for any
+}
+
+// parseRequireArgs parses the arguments following --require.
+func parseRequireArgs(argv []string) Op {
+	var op RequireOp
+	for i := 0; i < len(argv); i++ {
+		switch v := argv[i]; v {
+		case "--require-namespace":
+			if i+1 >= len(argv) || argv[i+1] == "" {
+				return UnsupportedOp{Err: fmt.Errorf("'--require-namespace' needs a namespace pattern")}
+			}
+			i++
+			op.Namespace = argv[i]
+		default:
+			if op.Context != "" || v == "" {
+				return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+			}
+			op.Context = v
+		}
+	}
+	if op.Context == "" {
+		return UnsupportedOp{Err: fmt.Errorf("'--require' needs a context name or pattern")}
+	}
+	return op
+}
+
+func (op RequireOp) Run(_, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	cur := kc.GetCurrentContext()
+	if cur == "" {
+		return errors.New("current-context is not set")
+	}
+	if !cmdutil.MatchGlob(op.Context, cur) {
+		return errors.Errorf("current context \"%s\" does not match \"%s\"", cur, op.Context)
+	}
+	if op.Namespace == "" {
+		return nil
+	}
+	ns, err := kc.NamespaceOfContext(cur)
+	if err != nil {
+		return errors.Wrap(err, "failed to read namespace of current context")
+	}
+	if !cmdutil.MatchGlob(op.Namespace, ns) {
+		return errors.Errorf("current namespace \"%s\" does not match \"%s\"", ns, op.Namespace)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// ResetOp indicates intention to remove the state files kubectx created.
+// Kubeconfig files and their backups are never removed.
+type ResetOp struct {
+	Yes bool // don't ask for confirmation
+}
+
+// parseResetArgs parses the arguments following --reset.
+func parseResetArgs(argv []string) Op {
+	var op ResetOp
+	for _, v := range argv {
+		if v != "-y" && v != "--yes" {
+			return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+		}
+		op.Yes = true
+	}
+	return op
+}
+
+// stateFiles returns the existing state files and directories of kubectx.
+func stateFiles() ([]string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{filepath.Join(dir, "kubectx-isolated")}
+	for _, f := range []func() (string, error){
+		kubectxPrevCtxFile,
+		kubectxCurCtxFile,
+		kubectxHistoryFile,
+		kubectxOrderFile,
+		kubectxLocksFile,
+		kubectxNotesFile,
+		kubectxUndoFile,
+	} {
+		path, err := f()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	var out []string
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// confirm asks the question, and returns true if the answer is yes.
+func confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	if _, err := fmt.Fprintf(out, "%s [y/N]: ", question); err != nil {
+		return false, errors.Wrap(err, "write error")
+	}
+	s := bufio.NewScanner(in)
+	if !s.Scan() {
+		fmt.Fprintln(out)
+		return false, errors.Wrap(s.Err(), "failed to read answer")
+	}
+	switch strings.ToLower(strings.TrimSpace(s.Text())) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (op ResetOp) Run(_, stderr io.Writer) error {
+	paths, err := stateFiles()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state files")
+	}
+	if len(paths) == 0 {
+		printer.Warning(stderr, "no state files to remove")
+		return nil
+	}
+
+	if !op.Yes {
+		fmt.Fprintln(stderr, "The following files will be removed (kubeconfig files are not modified):")
+		for _, p := range paths {
+			fmt.Fprintf(stderr, "  %s\n", p)
+		}
+		ok, err := confirm(os.Stdin, stderr, "Remove them?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("reset cancelled")
+		}
+	}
+
+	for _, p := range paths {
+		if err := os.RemoveAll(p); err != nil {
+			return errors.Wrapf(err, "failed to remove \"%s\"", p)
+		}
+	}
+	err = printer.Success(stderr, "Removed %d state files.", len(paths))
+	return errors.Wrap(err, "print error")
+}
@@ -0,0 +1,48 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_confirm(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{" y \r\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := confirm(strings.NewReader(tt.in), &out, "Remove?")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("confirm(%q)=%v; want=%v", tt.in, got, tt.want)
+		}
+		if !strings.HasPrefix(out.String(), "Remove? [y/N]: ") {
+			t.Errorf("unexpected prompt %q", out.String())
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// resolveContext determines which of the context names the user meant by
+// target. An exact match always wins. Otherwise, if ignoring case is enabled
+// with KUBECTX_IGNORE_CASE, target can match a single context name
+// regardless of case. Then, if fuzzy matching is enabled with KUBECTX_FUZZY,
+// target can be a substring of a single context name (regardless of case,
+// if ignoring it). In strict mode, only exact matches are accepted,
+// regardless of any fallback resolution that's enabled.
+func resolveContext(names []string, target string, strict bool) (string, error) {
+	for _, n := range names {
+		if n == target {
+			return n, nil
+		}
+	}
+	notFound := errors.Errorf("no context exists with the name: \"%s\"%s", target,
+		cmdutil.DidYouMean(cmdutil.Suggestions(target, names)))
+	if strict {
+		return "", notFound
+	}
+
+	ignoreCase := cmdutil.IsIgnoringCase()
+	if ignoreCase {
+		if name, ok, err := singleMatch(names, target, strings.EqualFold); ok || err != nil {
+			return name, err
+		}
+	}
+	if !cmdutil.IsFuzzyMatching() {
+		return "", notFound
+	}
+	contains := strings.Contains
+	if ignoreCase {
+		contains = func(s, substr string) bool {
+			return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+		}
+	}
+	if name, ok, err := singleMatch(names, target, contains); ok || err != nil {
+		return name, err
+	}
+	return "", notFound
+}
+
+// singleMatch returns the name matching target, and whether there's one. It
+// fails if target matches multiple names.
+func singleMatch(names []string, target string, match func(name, target string) bool) (string, bool, error) {
+	var matches []string
+	for _, n := range names {
+		if match(n, target) {
+			matches = append(matches, n)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+	natsort.Sort(matches)
+	return "", false, errors.Errorf("\"%s\" matches multiple contexts: %s", target, quoteJoin(matches))
+}
+
+// resolveSwitchTarget loads the kubeconfig to determine the context name
+// the user meant by target.
+func resolveSwitchTarget(target string, strict bool) (string, error) {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return "", errors.Wrap(err, "kubeconfig error")
+	}
+	return resolveContext(kc.ContextNames(), target, strict)
+}
+
+// ResolveOp prints the context name "kubectx <TARGET>" would switch to,
+// without switching.
+type ResolveOp struct {
+	Target string // '-' for the previous context, or NAME
+	Strict bool   // only accept an exact context name match
+}
+
+// parseResolveArgs parses the arguments following --resolve.
+func parseResolveArgs(argv []string) Op {
+	var op ResolveOp
+	var targets []string
+	for _, v := range argv {
+		if v == "--strict" {
+			op.Strict = true
+			continue
+		}
+		targets = append(targets, v)
+	}
+	if len(targets) != 1 || targets[0] == "" {
+		return UnsupportedOp{Err: fmt.Errorf("'--resolve' needs a context name")}
+	}
+	op.Target = targets[0]
+	return op
+}
+
+func (op ResolveOp) Run(stdout, _ io.Writer) error {
+	var name string
+	var err error
+	if op.Target == "-" {
+		name, err = previousContext()
+	} else {
+		name, err = resolveSwitchTarget(op.Target, op.Strict)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, name)
+	return errors.Wrap(err, "write error")
+}
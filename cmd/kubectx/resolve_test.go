@@ -0,0 +1,78 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_resolveContext(t *testing.T) {
+	names := []string{"prod", "prod-eu", "staging-us", "dev-us"}
+	tests := []struct {
+		name       string
+		fuzzy      string
+		ignoreCase string
+		names      []string
+		target     string
+		strict     bool
+		want       string
+		wantErr    bool
+	}{
+		{name: "exact match", target: "prod", want: "prod"},
+		{name: "no substring match by default", target: "staging", wantErr: true},
+		{name: "substring match", fuzzy: "1", target: "staging", want: "staging-us"},
+		{name: "exact match wins over substrings", fuzzy: "1", target: "prod", want: "prod"},
+		{name: "ambiguous substring", fuzzy: "1", target: "us", wantErr: true},
+		{name: "no match", fuzzy: "1", target: "qa", wantErr: true},
+		{name: "strict overrides env", fuzzy: "1", target: "staging", strict: true, wantErr: true},
+		{name: "env disabled with 0", fuzzy: "0", target: "staging", wantErr: true},
+		{name: "case-sensitive by default", target: "PROD", wantErr: true},
+		{name: "ignoring case", ignoreCase: "1", target: "PROD", want: "prod"},
+		{name: "exact match wins over ignoring case", ignoreCase: "1", target: "Prod", names: []string{"prod", "Prod"}, want: "Prod"},
+		{name: "ambiguous ignoring case", ignoreCase: "1", target: "PROD", names: []string{"prod", "Prod"}, wantErr: true},
+		{name: "strict overrides ignoring case", ignoreCase: "1", target: "PROD", strict: true, wantErr: true},
+		{name: "substring case-sensitive by default", fuzzy: "1", target: "STAGING", wantErr: true},
+		{name: "substring ignoring case", fuzzy: "1", ignoreCase: "1", target: "STAGING", want: "staging-us"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer testutil.WithEnvVar("KUBECTX_FUZZY", tt.fuzzy)()
+			defer testutil.WithEnvVar("KUBECTX_IGNORE_CASE", tt.ignoreCase)()
+			names := names
+			if tt.names != nil {
+				names = tt.names
+			}
+			got, err := resolveContext(names, tt.target, tt.strict)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveContext() err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("resolveContext()=%q, want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resolveContext_suggestions(t *testing.T) {
+	defer testutil.WithEnvVar("KUBECTX_FUZZY", "")()
+	defer testutil.WithEnvVar("KUBECTX_IGNORE_CASE", "")()
+	_, err := resolveContext([]string{"prod", "prod-eu", "staging"}, "prdo", false)
+	want := `no context exists with the name: "prdo" (did you mean "prod"?)`
+	if err == nil || err.Error() != want {
+		t.Fatalf("resolveContext() err=%v, want=%q", err, want)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// SelectOp indicates intention to switch to the context at a position of the
+// list, as printed by the ListOp with the same flags.
+type SelectOp struct {
+	Index int    // 1-based position in the list
+	List  ListOp // flags the list is sorted and filtered with
+}
+
+// parseSelectArgs parses the arguments following --select.
+func parseSelectArgs(argv []string) Op {
+	if len(argv) == 0 {
+		return UnsupportedOp{Err: fmt.Errorf("'--select' needs a position")}
+	}
+	n, err := strconv.Atoi(argv[0])
+	if err != nil || n < 1 {
+		return UnsupportedOp{Err: fmt.Errorf("invalid position %q", argv[0])}
+	}
+	list, err := parseListOptions(argv[1:])
+	if err != nil {
+		return UnsupportedOp{Err: err}
+	}
+	if list.Output != "" || list.NoHeaders || list.ShowLastUsed || list.ShowSource {
+		return UnsupportedOp{Err: fmt.Errorf("'--select' only combines with the flags choosing and ordering the contexts")}
+	}
+	return SelectOp{Index: n, List: list}
+}
+
+func (op SelectOp) Run(stdout, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	if err := kc.Parse(); err != nil {
+		kc.Close()
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	ctxs, _, err := op.List.contexts(kc)
+	kc.Close() // SwitchOp loads the kubeconfig again
+	if err != nil {
+		return err
+	}
+
+	name, err := selectContext(ctxs, op.Index)
+	if err != nil {
+		return err
+	}
+	return SwitchOp{Target: name}.Run(stdout, stderr)
+}
+
+// selectContext returns the context at the 1-based position of ctxs.
+func selectContext(ctxs []string, n int) (string, error) {
+	if n > len(ctxs) {
+		return "", errors.Errorf("no context at position %d, there are %d", n, len(ctxs))
+	}
+	return ctxs[n-1], nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// SetNamespaceOp indicates intention to change the namespace of the current
+// context, the same way kubens does.
+type SetNamespaceOp struct {
+	Namespace string // '-' for the previous namespace, or NAME
+}
+
+func (op SetNamespaceOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	ns, err := namespace.Switch(kc, op.Namespace, false)
+	if err != nil {
+		return err
+	}
+	return printer.Success(stderr, "Active namespace is \"%s\"", printer.SuccessColor.Sprint(ns))
+}
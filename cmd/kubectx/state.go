@@ -24,12 +24,43 @@ import (
 	"github.com/ahmetb/kubectx/internal/cmdutil"
 )
 
-func kubectxPrevCtxFile() (string, error) {
+// kubeDir returns the directory kubectx stores its state files in.
+func kubeDir() (string, error) {
 	home := cmdutil.HomeDir()
 	if home == "" {
 		return "", errors.New("HOME or USERPROFILE environment variable not set")
 	}
-	return filepath.Join(home, ".kube", "kubectx"), nil
+	return filepath.Join(home, ".kube"), nil
+}
+
+func kubectxPrevCtxFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.StateFile("previous-context", filepath.Join(dir, "kubectx")), nil
+}
+
+// kubectxCurCtxFile returns the path of the state file recording the context
+// kubectx last switched to, to tell if another tool changed current-context
+// since then.
+func kubectxCurCtxFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.StateFile("current-context", filepath.Join(dir, "kubectx-current")), nil
+}
+
+// reconcilePrevious returns the previous context given the saved one, the
+// context kubectx last switched to (recorded) and the current context in
+// kubeconfig. If another tool changed current-context since kubectx switched,
+// the context it switched to is the previous one.
+func reconcilePrevious(prev, recorded, cur string) string {
+	if recorded != "" && recorded != cur {
+		return recorded
+	}
+	return prev
 }
 
 // readLastContext returns the saved previous context
@@ -45,9 +76,159 @@ func readLastContext(path string) (string, error) {
 // writeLastContext saves the specified value to the state file.
 // It creates missing parent directories.
 func writeLastContext(path, value string) error {
+	path = cmdutil.WritableStateFile(path)
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return errors.Wrap(err, "failed to create parent directories")
 	}
 	return ioutil.WriteFile(path, []byte(value), 0644)
 }
+
+// renameInList returns the names with p.Old replaced by p.New. Existing
+// occurrences of p.New are dropped, as they referred to a context that was
+// overwritten by the rename.
+func renameInList(names []string, p renamePair) []string {
+	if p.Old == p.New {
+		return names
+	}
+	var out []string
+	for _, v := range names {
+		switch v {
+		case p.New:
+			continue
+		case p.Old:
+			out = append(out, p.New)
+		default:
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// renamePrevious returns the previous context name after the renames. It is
+// cleared if the previous context was overwritten by a rename, so that
+// "kubectx -" doesn't switch to the renamed context instead.
+func renamePrevious(prev string, renames []renamePair) string {
+	for _, p := range renames {
+		if p.Old == p.New {
+			continue
+		}
+		switch prev {
+		case p.Old:
+			prev = p.New
+		case p.New:
+			prev = ""
+		}
+	}
+	return prev
+}
+
+// renameStateReferences updates the context names saved in the state files
+// (previous and current context, history, ordering, locks and notes) after the renames,
+// so they keep referring to the same contexts.
+func renameStateReferences(renames []renamePair) error {
+	if len(renames) == 0 {
+		return nil
+	}
+
+	prevFile, err := kubectxPrevCtxFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	prev, err := readLastContext(prevFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read previous context file")
+	}
+	if v := renamePrevious(prev, renames); v != prev {
+		if err := writeLastContext(prevFile, v); err != nil {
+			return errors.Wrap(err, "failed to save previous context name")
+		}
+	}
+
+	curFile, err := kubectxCurCtxFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	recorded, err := readLastContext(curFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read current context file")
+	}
+	if v := renamePrevious(recorded, renames); v != recorded {
+		if err := writeLastContext(curFile, v); err != nil {
+			return errors.Wrap(err, "failed to save current context name")
+		}
+	}
+
+	historyFile, err := kubectxHistoryFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine history file")
+	}
+	history, err := readHistory(historyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read history")
+	}
+	if history != nil {
+		for _, p := range renames {
+			history = renameInHistory(history, p)
+		}
+		if err := writeHistory(historyFile, history); err != nil {
+			return errors.Wrap(err, "failed to save history")
+		}
+	}
+
+	orderFile, err := kubectxOrderFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	order, err := readOrder(orderFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read ordering file")
+	}
+	if order != nil {
+		for _, p := range renames {
+			order = renameInList(order, p)
+		}
+		if err := writeOrder(orderFile, order); err != nil {
+			return errors.Wrap(err, "failed to save ordering file")
+		}
+	}
+
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+	if locks != nil {
+		for _, p := range renames {
+			locks = renameInList(locks, p)
+		}
+		if err := writeLocks(locks); err != nil {
+			return err
+		}
+	}
+
+	notesFile, err := kubectxNotesFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	notes, err := readNotes(notesFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read notes file")
+	}
+	if notes != nil {
+		for _, p := range renames {
+			if p.Old == p.New {
+				continue
+			}
+			note, ok := notes[p.Old]
+			delete(notes, p.Old)
+			delete(notes, p.New)
+			if ok {
+				notes[p.New] = note
+			}
+		}
+		if err := writeNotes(notesFile, notes); err != nil {
+			return errors.Wrap(err, "failed to save notes file")
+		}
+	}
+	return nil
+}
@@ -20,6 +20,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ahmetb/kubectx/internal/env"
 	"github.com/ahmetb/kubectx/internal/testutil"
 )
 
@@ -78,6 +81,8 @@ func Test_kubectxFilePath(t *testing.T) {
 	origHome := os.Getenv("HOME")
 	os.Setenv("HOME", filepath.FromSlash("/foo/bar"))
 	defer os.Setenv("HOME", origHome)
+	defer testutil.WithEnvVar("XDG_STATE_HOME", "")()
+	defer testutil.WithEnvVar("XDG_CACHE_HOME", "")()
 
 	expected := filepath.Join(filepath.FromSlash("/foo/bar"), ".kube", "kubectx")
 	v, err := kubectxPrevCtxFile()
@@ -89,6 +94,20 @@ func Test_kubectxFilePath(t *testing.T) {
 	}
 }
 
+func Test_kubectxFilePath_xdgStateHome(t *testing.T) {
+	defer testutil.WithEnvVar("HOME", filepath.FromSlash("/foo/bar"))()
+	defer testutil.WithEnvVar("XDG_STATE_HOME", os.TempDir())()
+
+	expected := filepath.Join(os.TempDir(), "kubectx", "previous-context")
+	v, err := kubectxPrevCtxFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != expected {
+		t.Fatalf("expected=\"%s\" got=\"%s\"", expected, v)
+	}
+}
+
 func Test_kubectxFilePath_error(t *testing.T) {
 	origHome := os.Getenv("HOME")
 	origUserprofile := os.Getenv("USERPROFILE")
@@ -102,3 +121,140 @@ func Test_kubectxFilePath_error(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_renameInList(t *testing.T) {
+	tests := []struct {
+		names []string
+		p     renamePair
+		want  []string
+	}{
+		{nil, renamePair{"a", "b"}, nil},
+		{[]string{"x", "a", "y"}, renamePair{"a", "b"}, []string{"x", "b", "y"}},
+		{[]string{"b", "x", "a"}, renamePair{"a", "b"}, []string{"x", "b"}},
+		{[]string{"a", "x"}, renamePair{"a", "a"}, []string{"a", "x"}},
+	}
+	for _, tt := range tests {
+		got := renameInList(tt.names, tt.p)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("renameInList(%v, %v) diff=%s", tt.names, tt.p, diff)
+		}
+	}
+}
+
+func Test_renamePrevious(t *testing.T) {
+	tests := []struct {
+		prev    string
+		renames []renamePair
+		want    string
+	}{
+		{"", []renamePair{{"a", "b"}}, ""},
+		{"a", []renamePair{{"a", "b"}}, "b"},
+		{"x", []renamePair{{"a", "b"}}, "x"},
+		{"b", []renamePair{{"a", "b"}}, ""},
+		{"a", []renamePair{{"a", "a"}}, "a"},
+		{"y", []renamePair{{"x", "x2"}, {"y", "y2"}}, "y2"},
+	}
+	for _, tt := range tests {
+		if got := renamePrevious(tt.prev, tt.renames); got != tt.want {
+			t.Errorf("renamePrevious(%q, %v) = %q; want=%q", tt.prev, tt.renames, got, tt.want)
+		}
+	}
+}
+
+func Test_renameStateReferences(t *testing.T) {
+	testutil.TempHome(t)
+
+	prevFile, err := kubectxPrevCtxFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeLastContext(prevFile, "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordContextUse("old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := renameStateReferences([]renamePair{{Old: "old", New: "new"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	prev, err := readLastContext(prevFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != "new" {
+		t.Fatalf("expected previous context to be renamed; got=\"%s\"", prev)
+	}
+	historyFile, err := kubectxHistoryFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, err := readHistory(historyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Context != "new" {
+		t.Fatalf("expected history entry to be renamed; got=%v", history)
+	}
+}
+
+func Test_reconcilePrevious(t *testing.T) {
+	tests := []struct {
+		name                string
+		prev, recorded, cur string
+		want                string
+	}{
+		{name: "nothing recorded", prev: "a", cur: "b", want: "a"},
+		{name: "unchanged", prev: "a", recorded: "b", cur: "b", want: "a"},
+		{name: "changed by another tool", prev: "a", recorded: "b", cur: "c", want: "b"},
+		{name: "unset by another tool", prev: "a", recorded: "b", want: "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reconcilePrevious(tt.prev, tt.recorded, tt.cur); got != tt.want {
+				t.Fatalf("got=%q; want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_previousContext_externalSwitch(t *testing.T) {
+	dir := testutil.TempHome(t)
+	defer testutil.WithEnvVar(env.EnvPrevious, "")()
+
+	// useContext changes current-context like another tool would
+	useContext := func(name string) {
+		testutil.TempKubeconfig(t, dir, testutil.KC().WithCurrentCtx(name).
+			WithCtxs(testutil.Ctx("a"), testutil.Ctx("b"), testutil.Ctx("c")))
+	}
+	wantPrevious := func(want string) {
+		t.Helper()
+		got, err := previousContext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("previous context=%q; want=%q", got, want)
+		}
+	}
+
+	useContext("a")
+	if _, err := switchContext("c"); err != nil {
+		t.Fatal(err)
+	}
+	wantPrevious("a")
+
+	useContext("b")
+	wantPrevious("c")
+
+	// switching to the context another tool switched to keeps the previous one
+	if _, err := switchContext("b"); err != nil {
+		t.Fatal(err)
+	}
+	wantPrevious("c")
+
+	if _, err := switchContext("c"); err != nil {
+		t.Fatal(err)
+	}
+	wantPrevious("b")
+}
@@ -0,0 +1,120 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// StatsOp indicates intention to print how many times each context has been
+// switched to.
+type StatsOp struct {
+	Output string // output format, "" for plain text or "json"
+}
+
+// ResetStatsOp indicates intention to clear the context usage statistics.
+type ResetStatsOp struct{}
+
+// contextStats is the usage statistics of a context.
+type contextStats struct {
+	Context  string    `json:"context"`
+	Switches int       `json:"switches"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// parseStatsArgs parses the arguments following --stats.
+func parseStatsArgs(argv []string) Op {
+	switch {
+	case len(argv) == 0:
+		return StatsOp{}
+	case len(argv) == 2 && (argv[0] == "-o" || argv[0] == "--output"):
+		if argv[1] != outputJSON {
+			return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[1])}
+		}
+		return StatsOp{Output: argv[1]}
+	}
+	return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+}
+
+// usageStats returns the statistics of the contexts switched to at least
+// once, most switched first.
+func usageStats(entries []historyEntry) []contextStats {
+	out := []contextStats{}
+	for _, e := range entries {
+		if e.Switches > 0 {
+			out = append(out, contextStats{Context: e.Context, Switches: e.Switches, LastUsed: e.LastUsed})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Switches != out[j].Switches {
+			return out[i].Switches > out[j].Switches
+		}
+		return out[i].Context < out[j].Context
+	})
+	return out
+}
+
+func (op StatsOp) Run(stdout, _ io.Writer) error {
+	path, err := kubectxHistoryFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine history file")
+	}
+	entries, err := readHistory(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read history")
+	}
+	stats := usageStats(entries)
+
+	if op.Output == outputJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(stats), "write error")
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, s := range stats {
+		fmt.Fprintf(w, "%s\t%d\n", s.Context, s.Switches)
+	}
+	return errors.Wrap(w.Flush(), "write error")
+}
+
+func (_ ResetStatsOp) Run(_, stderr io.Writer) error {
+	path, err := kubectxHistoryFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine history file")
+	}
+	entries, err := readHistory(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read history")
+	}
+	if entries != nil {
+		for i := range entries {
+			entries[i].Switches = 0
+		}
+		if err := writeHistory(path, entries); err != nil {
+			return errors.Wrap(err, "failed to save history")
+		}
+	}
+	err = printer.Success(stderr, "Cleared the context usage statistics.")
+	return errors.Wrap(err, "print error")
+}
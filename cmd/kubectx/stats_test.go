@@ -0,0 +1,43 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_usageStats(t *testing.T) {
+	t0 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []historyEntry{
+		{Context: "c", LastUsed: t0.Add(3 * time.Hour), Switches: 1},
+		{Context: "touched", LastUsed: t0.Add(2 * time.Hour)},
+		{Context: "b", LastUsed: t0.Add(time.Hour), Switches: 5},
+		{Context: "a", LastUsed: t0, Switches: 1},
+	}
+	want := []contextStats{
+		{Context: "b", Switches: 5, LastUsed: t0.Add(time.Hour)},
+		{Context: "a", Switches: 1, LastUsed: t0},
+		{Context: "c", Switches: 1, LastUsed: t0.Add(3 * time.Hour)},
+	}
+	if diff := cmp.Diff(want, usageStats(entries)); diff != "" {
+		t.Fatalf("usageStats() diff=%s", diff)
+	}
+	if diff := cmp.Diff([]contextStats{}, usageStats(nil)); diff != "" {
+		t.Fatalf("usageStats(nil) diff=%s", diff)
+	}
+}
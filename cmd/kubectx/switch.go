@@ -15,32 +15,65 @@
 package main
 
 import (
+	"fmt"
 	"io"
+	"os"
 
 	"github.com/pkg/errors"
 
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
 	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
 	"github.com/ahmetb/kubectx/internal/printer"
 )
 
 // SwitchOp indicates intention to switch contexts.
 type SwitchOp struct {
-	Target string // '-' for back and forth, or NAME
+	Target  string // '-' for back and forth, or NAME
+	Strict  bool   // only switch to an exact context name match
+	Isolate bool   // also write the context to its own kubeconfig file
+	Yes     bool   // don't confirm switching to contexts matching the danger pattern
+
+	WarnCrossProject bool // warn if the switch changes the cloud project or account
 }
 
-func (op SwitchOp) Run(_, stderr io.Writer) error {
-	var newCtx string
+func (op SwitchOp) Run(stdout, stderr io.Writer) error {
+	var newCtx, oldCtx string
 	var err error
+	warn := op.WarnCrossProject || cmdutil.IsCrossProjectWarning()
+	if warn {
+		oldCtx = currentContextName()
+	}
 	if op.Target == "-" {
-		newCtx, err = swapContext()
+		newCtx, err = previousContext()
 	} else {
-		newCtx, err = switchContext(op.Target)
+		newCtx, err = resolveSwitchTarget(op.Target, op.Strict)
+	}
+	if err == nil {
+		err = confirmSwitch(stderr, newCtx, op.Yes)
+	}
+	if err == nil {
+		newCtx, err = switchContext(newCtx)
 	}
 	if err != nil {
 		return errors.Wrap(err, "failed to switch context")
 	}
+	audit(stderr, auditEntry{Operation: auditSwitch, Target: newCtx})
 	err = printer.Success(stderr, "Switched to context \"%s\".", printer.SuccessColor.Sprint(newCtx))
-	return errors.Wrap(err, "print error")
+	if warn {
+		warnCrossProject(stderr, oldCtx, newCtx)
+	}
+	if err != nil || !op.Isolate {
+		return errors.Wrap(err, "print error")
+	}
+
+	path, err := writeIsolatedKubeconfig(newCtx)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "export KUBECONFIG=%s\n", cmdutil.ShellQuote(path))
+	return errors.Wrap(err, "write error")
 }
 
 // switchContext switches to specified context name.
@@ -49,6 +82,10 @@ func switchContext(name string) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "failed to determine state file")
 	}
+	curCtxFile, err := kubectxCurCtxFile()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to determine state file")
+	}
 
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
 	defer kc.Close()
@@ -57,12 +94,22 @@ func switchContext(name string) (string, error) {
 	}
 
 	prev := kc.GetCurrentContext()
+	if prev == name {
+		// already current, possibly switched to by another tool since kubectx
+		// last switched, so keep the previous context "kubectx -" would use
+		if prev, err = savedPreviousContext(prev); err != nil {
+			return "", err
+		}
+	}
 	if !kc.ContextExists(name) {
 		return "", errors.Errorf("no context exists with the name: \"%s\"", name)
 	}
 	if err := kc.ModifyCurrentContext(name); err != nil {
 		return "", err
 	}
+	if err := restoreSessionNamespace(kc, name); err != nil {
+		return "", err
+	}
 	if err := kc.Save(); err != nil {
 		return "", errors.Wrap(err, "failed to save kubeconfig")
 	}
@@ -72,11 +119,55 @@ func switchContext(name string) (string, error) {
 			return "", errors.Wrap(err, "failed to save previous context name")
 		}
 	}
+	if err := writeLastContext(curCtxFile, name); err != nil {
+		return "", errors.Wrap(err, "failed to save current context name")
+	}
+	if err := recordContextSwitch(name); err != nil {
+		return "", errors.Wrap(err, "failed to save context history")
+	}
 	return name, nil
 }
 
-// swapContext switches to previously switch context.
-func swapContext() (string, error) {
+// restoreSessionNamespace sets the namespace of the context to the one last
+// used in it in this shell session, if session namespaces are enabled.
+func restoreSessionNamespace(kc *kubeconfig.Kubeconfig, name string) error {
+	ns, err := namespace.SessionNamespace(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the namespace of this session")
+	}
+	if ns == "" {
+		return nil
+	}
+	cur, err := kc.NamespaceOfContext(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace of context")
+	}
+	if cur == ns {
+		return nil
+	}
+	return errors.Wrapf(kc.SetNamespace(name, ns), "failed to restore namespace \"%s\"", ns)
+}
+
+// previousContext returns the context "kubectx -" switches to, without
+// modifying any files.
+func previousContext() (string, error) {
+	if v := os.Getenv(env.EnvPrevious); v != "" {
+		return v, nil
+	}
+	prev, err := savedPreviousContext(currentContextName())
+	if err != nil {
+		return "", err
+	}
+	if prev == "" {
+		return "", errors.New("no previous context found")
+	}
+	return prev, nil
+}
+
+// savedPreviousContext returns the previous context in the state files,
+// reconciled with the current context cur in kubeconfig, or "" if there's
+// none.
+func savedPreviousContext(cur string) (string, error) {
 	prevCtxFile, err := kubectxPrevCtxFile()
 	if err != nil {
 		return "", errors.Wrap(err, "failed to determine state file")
@@ -85,8 +176,26 @@ func swapContext() (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "failed to read previous context file")
 	}
-	if prev == "" {
-		return "", errors.New("no previous context found")
+	curCtxFile, err := kubectxCurCtxFile()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to determine state file")
+	}
+	recorded, err := readLastContext(curCtxFile)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read current context file")
+	}
+	return reconcilePrevious(prev, recorded, cur), nil
+}
+
+// PeekOp indicates intention to print the context "kubectx -" would switch
+// to, without switching.
+type PeekOp struct{}
+
+func (_ PeekOp) Run(stdout, _ io.Writer) error {
+	prev, err := previousContext()
+	if err != nil {
+		return err
 	}
-	return switchContext(prev)
+	_, err = fmt.Fprintln(stdout, prev)
+	return errors.Wrap(err, "write error")
 }
@@ -0,0 +1,75 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_switchContext_multipleFiles(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		order []string
+	}{
+		{name: "current-context file first", order: []string{"a", "b"}},
+		{name: "current-context file last", order: []string{"b", "a"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testutil.TempHome(t)
+
+			// file "a" sets current-context, file "b" defines the context to switch to
+			files := map[string]string{
+				"a": testutil.KC().WithCurrentCtx("ctx-a").WithCtxs(testutil.Ctx("ctx-a")).ToYAML(t),
+				"b": testutil.KC().WithCtxs(testutil.Ctx("ctx-b")).ToYAML(t),
+			}
+			var paths []string
+			for _, f := range tt.order {
+				path := filepath.Join(dir, f)
+				if err := ioutil.WriteFile(path, []byte(files[f]), 0644); err != nil {
+					t.Fatal(err)
+				}
+				paths = append(paths, path)
+			}
+			defer testutil.WithEnvVar("KUBECONFIG", strings.Join(paths, string(filepath.ListSeparator)))()
+
+			if _, err := switchContext("ctx-b"); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := ioutil.ReadFile(filepath.Join(dir, "b"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != files["b"] {
+				t.Errorf("file defining the context was modified:\n%s", b)
+			}
+			a, err := ioutil.ReadFile(filepath.Join(dir, "a"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(a), "current-context: ctx-b") {
+				t.Errorf("current-context not set in the file defining it:\n%s", a)
+			}
+			if strings.Contains(string(a), "name: ctx-b") {
+				t.Errorf("context was copied to the file setting current-context:\n%s", a)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// TouchOp indicates intention to mark a context as recently used
+// without switching to it.
+type TouchOp struct {
+	Context string
+}
+
+func (op TouchOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	if !kc.ContextExists(op.Context) {
+		return errors.Errorf("no context exists with the name: \"%s\"", op.Context)
+	}
+
+	if err := recordContextUse(op.Context); err != nil {
+		return errors.Wrap(err, "failed to save context history")
+	}
+	err := printer.Success(stderr, "Marked context \"%s\" as recently used.", printer.SuccessColor.Sprint(op.Context))
+	return errors.Wrap(err, "print error")
+}
@@ -0,0 +1,189 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"facette.io/natsort"
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// TreeOp indicates intention to print the contexts grouped by cloud provider,
+// project and cluster, as inferred from their names.
+type TreeOp struct {
+	Output string // output format, "" for a tree or "json"
+}
+
+// contextTree is the contexts grouped by cloud provider, project and cluster.
+type contextTree struct {
+	Providers []treeProvider `json:"providers"`
+	Other     []string       `json:"other"` // contexts of no recognized provider
+}
+
+type treeProvider struct {
+	Name     string        `json:"name"`
+	Projects []treeProject `json:"projects"`
+}
+
+type treeProject struct {
+	Name     string        `json:"name"`
+	Clusters []treeCluster `json:"clusters"`
+}
+
+type treeCluster struct {
+	Name     string   `json:"name"`
+	Location string   `json:"location"`
+	Contexts []string `json:"contexts"`
+}
+
+// parseTreeArgs parses the arguments following --tree.
+func parseTreeArgs(argv []string) Op {
+	switch {
+	case len(argv) == 0:
+		return TreeOp{}
+	case len(argv) == 2 && (argv[0] == "-o" || argv[0] == "--output"):
+		if argv[1] != outputJSON {
+			return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[1])}
+		}
+		return TreeOp{Output: argv[1]}
+	}
+	return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+}
+
+// buildTree groups the contexts by the cloud clusters returned for them, with
+// each level sorted naturally. Contexts without a cloud cluster are listed
+// in Other.
+func buildTree(ctxs []string, cluster func(ctx string) (cloudCluster, bool)) contextTree {
+	byCluster := make(map[cloudCluster][]string)
+	other := []string{}
+	for _, ctx := range ctxs {
+		if c, ok := cluster(ctx); ok {
+			byCluster[c] = append(byCluster[c], ctx)
+		} else {
+			other = append(other, ctx)
+		}
+	}
+	clusters := make([]cloudCluster, 0, len(byCluster))
+	for c := range byCluster {
+		clusters = append(clusters, c)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		a, b := clusters[i], clusters[j]
+		for _, v := range [][2]string{{a.Provider, b.Provider}, {a.Project, b.Project}, {a.Name, b.Name}} {
+			if v[0] != v[1] {
+				return natsort.Compare(v[0], v[1])
+			}
+		}
+		return natsort.Compare(a.Location, b.Location)
+	})
+
+	tree := contextTree{Providers: []treeProvider{}, Other: other}
+	for _, c := range clusters {
+		if n := len(tree.Providers); n == 0 || tree.Providers[n-1].Name != c.Provider {
+			tree.Providers = append(tree.Providers, treeProvider{Name: c.Provider})
+		}
+		provider := &tree.Providers[len(tree.Providers)-1]
+		if n := len(provider.Projects); n == 0 || provider.Projects[n-1].Name != c.Project {
+			provider.Projects = append(provider.Projects, treeProject{Name: c.Project})
+		}
+		project := &provider.Projects[len(provider.Projects)-1]
+
+		names := byCluster[c]
+		natsort.Sort(names)
+		project.Clusters = append(project.Clusters, treeCluster{Name: c.Name, Location: c.Location, Contexts: names})
+	}
+	natsort.Sort(tree.Other)
+	return tree
+}
+
+// printTree prints the tree with box-drawing characters, and the contexts of
+// no recognized provider under "other". The current context cur is
+// highlighted.
+func printTree(w io.Writer, tree contextTree, cur string) error {
+	var lines []string
+	add := func(prefix string, last bool, s string) string {
+		branch, next := "├── ", "│   "
+		if last {
+			branch, next = "└── ", "    "
+		}
+		lines = append(lines, prefix+branch+s)
+		return prefix + next
+	}
+	ctxName := func(ctx string) string {
+		if ctx == cur {
+			return printer.ActiveItemColor.Sprint(ctx)
+		}
+		return ctx
+	}
+
+	for _, p := range tree.Providers {
+		lines = append(lines, p.Name)
+		for i, pr := range p.Projects {
+			prefix := add("", i == len(p.Projects)-1, pr.Name)
+			for j, c := range pr.Clusters {
+				label := c.Name
+				if c.Location != "" {
+					label = fmt.Sprintf("%s (%s)", c.Name, c.Location)
+				}
+				prefix := add(prefix, j == len(pr.Clusters)-1, label)
+				for k, ctx := range c.Contexts {
+					add(prefix, k == len(c.Contexts)-1, ctxName(ctx))
+				}
+			}
+		}
+	}
+	if len(tree.Other) > 0 {
+		lines = append(lines, "other")
+		for i, ctx := range tree.Other {
+			add("", i == len(tree.Other)-1, ctxName(ctx))
+		}
+	}
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(w, l); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
+
+func (op TreeOp) Run(stdout, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		if cmdutil.IsNotFoundErr(err) {
+			printer.Warning(stderr, "kubeconfig file not found")
+			return nil
+		}
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	tree := buildTree(kc.ContextNames(), func(ctx string) (cloudCluster, bool) {
+		return contextCluster(kc, ctx)
+	})
+	if op.Output == outputJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(tree), "write error")
+	}
+	return printTree(stdout, tree, kc.GetCurrentContext())
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var treeContexts = []string{
+	"minikube",
+	"gke_proj-b_us-east1_prod",
+	"arn:aws:eks:us-east-1:123456789012:cluster/prod",
+	"gke_proj-a_europe-west1_dev",
+	"prod-admin", // a renamed context of gke_proj-b_us-east1_prod
+	"gke_proj-a_europe-west1_prod",
+	"kind-kind",
+}
+
+// clusterOfTestContext is contextCluster for treeContexts, without a kubeconfig.
+func clusterOfTestContext(ctx string) (cloudCluster, bool) {
+	if ctx == "prod-admin" {
+		return parseCloudCluster("gke_proj-b_us-east1_prod")
+	}
+	return parseCloudCluster(ctx)
+}
+
+func Test_buildTree(t *testing.T) {
+	got := buildTree(treeContexts, clusterOfTestContext)
+	want := contextTree{
+		Providers: []treeProvider{
+			{Name: "EKS", Projects: []treeProject{
+				{Name: "123456789012", Clusters: []treeCluster{
+					{Name: "prod", Location: "us-east-1", Contexts: []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod"}},
+				}},
+			}},
+			{Name: "GKE", Projects: []treeProject{
+				{Name: "proj-a", Clusters: []treeCluster{
+					{Name: "dev", Location: "europe-west1", Contexts: []string{"gke_proj-a_europe-west1_dev"}},
+					{Name: "prod", Location: "europe-west1", Contexts: []string{"gke_proj-a_europe-west1_prod"}},
+				}},
+				{Name: "proj-b", Clusters: []treeCluster{
+					{Name: "prod", Location: "us-east1", Contexts: []string{"gke_proj-b_us-east1_prod", "prod-admin"}},
+				}},
+			}},
+		},
+		Other: []string{"kind-kind", "minikube"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("buildTree() diff=%s", diff)
+	}
+}
+
+func Test_printTree(t *testing.T) {
+	var out bytes.Buffer
+	if err := printTree(&out, buildTree(treeContexts, clusterOfTestContext), ""); err != nil {
+		t.Fatal(err)
+	}
+	want := `EKS
+└── 123456789012
+    └── prod (us-east-1)
+        └── arn:aws:eks:us-east-1:123456789012:cluster/prod
+GKE
+├── proj-a
+│   ├── dev (europe-west1)
+│   │   └── gke_proj-a_europe-west1_dev
+│   └── prod (europe-west1)
+│       └── gke_proj-a_europe-west1_prod
+└── proj-b
+    └── prod (us-east1)
+        ├── gke_proj-b_us-east1_prod
+        └── prod-admin
+other
+├── kind-kind
+└── minikube
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Fatalf("printTree() diff=%s", diff)
+	}
+}
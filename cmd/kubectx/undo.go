@@ -0,0 +1,206 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// UndoOp indicates intention to revert the last rename or delete.
+type UndoOp struct{}
+
+// undoEntry records the last rename or delete operation, so it can be
+// reverted. Renames (all of them for bulk renames) are reverted first, then
+// the deleted contexts are restored.
+type undoEntry struct {
+	Renames []renamePair     `json:"renames,omitempty"`
+	Deleted []deletedContext `json:"deleted,omitempty"`
+}
+
+// deletedContext is a context entry removed from the kubeconfig.
+type deletedContext struct {
+	Name   string `json:"name"`
+	Entry  string `json:"entry"`            // context entry as a YAML document
+	Locked bool   `json:"locked,omitempty"` // the lock is restored with it
+}
+
+func kubectxUndoFile() (string, error) {
+	dir, err := kubeDir()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.StateFile("undo", filepath.Join(dir, "kubectx-undo")), nil
+}
+
+// readUndo returns the saved undo entry, or nil if there's nothing to undo.
+func readUndo(path string) (*undoEntry, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var v undoEntry
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, errors.Wrap(err, "failed to decode undo file")
+	}
+	return &v, nil
+}
+
+// writeUndo saves the undo entry to the file, replacing the previous one.
+// It creates missing parent directories.
+func writeUndo(path string, e undoEntry) error {
+	path = cmdutil.WritableStateFile(path)
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode undo entry")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create parent directories")
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// recordUndo saves the undo entry for the operation that was just done.
+func recordUndo(e undoEntry) error {
+	path, err := kubectxUndoFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	return errors.Wrap(writeUndo(path, e), "failed to save undo information")
+}
+
+// undoRenames returns the renames reverting the recorded renames, in reverse
+// order, given which contexts exist now. Renames that were already reverted,
+// e.g. by hand after a partial failure, are skipped and returned separately.
+// It fails if any context was renamed or created since, so nothing is undone
+// halfway.
+func undoRenames(renames []renamePair, exists func(string) bool) (reverts []renamePair, skipped []renamePair, err error) {
+	for i := len(renames) - 1; i >= 0; i-- {
+		p := renames[i]
+		switch newExists, oldExists := exists(p.New), exists(p.Old); {
+		case newExists && !oldExists:
+			reverts = append(reverts, renamePair{Old: p.New, New: p.Old})
+		case !newExists && oldExists:
+			skipped = append(skipped, p)
+		case !newExists:
+			return nil, nil, errors.Errorf("context \"%s\" no longer exists, can't undo its rename", p.New)
+		default:
+			return nil, nil, errors.Errorf("context \"%s\" exists, can't undo the rename to it", p.Old)
+		}
+	}
+	return reverts, skipped, nil
+}
+
+func (UndoOp) Run(_, stderr io.Writer) error {
+	path, err := kubectxUndoFile()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine state file")
+	}
+	e, err := readUndo(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read undo file")
+	}
+	if e == nil {
+		return errors.New("nothing to undo")
+	}
+
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	cur := kc.GetCurrentContext()
+	reverts, skipped, err := undoRenames(e.Renames, kc.ContextExists)
+	if err != nil {
+		return err
+	}
+	for _, p := range reverts {
+		if err := kc.ModifyContextName(p.Old, p.New); err != nil {
+			return errors.Wrapf(err, "failed to change context name \"%s\"", p.Old)
+		}
+		if p.Old == cur {
+			if err := kc.ModifyCurrentContext(p.New); err != nil {
+				return errors.Wrap(err, "failed to set current-context to old name")
+			}
+		}
+	}
+	for _, d := range e.Deleted {
+		if err := kc.AddContextEntry([]byte(d.Entry)); err != nil {
+			return errors.Wrapf(err, "failed to restore context \"%s\"", d.Name)
+		}
+	}
+	if err := kc.Save(); err != nil {
+		return errors.Wrap(err, "failed to save modified kubeconfig")
+	}
+	// the kubeconfig is reverted, so the entry must not be undone again even
+	// if updating the state files fails
+	if err := os.Remove(path); err != nil {
+		return errors.Wrap(err, "failed to clear undo file")
+	}
+	if err := renameStateReferences(reverts); err != nil {
+		return errors.Wrap(err, "failed to update state files with the old names")
+	}
+	if err := restoreLocks(e.Deleted); err != nil {
+		return errors.Wrap(err, "failed to restore locks of the deleted contexts")
+	}
+
+	for _, p := range skipped {
+		printer.Warning(stderr, "context \"%s\" was already renamed back from \"%s\"", p.Old, p.New)
+	}
+	for _, p := range reverts {
+		printRenamed(stderr, p.Old, p.New)
+		audit(stderr, auditEntry{Operation: auditRename, Target: p.Old, NewName: p.New})
+		runRenameHook(stderr, p.Old, p.New)
+	}
+	for _, d := range e.Deleted {
+		printer.Success(stderr, "Restored context %s.", printer.SuccessColor.Sprint(d.Name))
+		audit(stderr, auditEntry{Operation: auditRestore, Target: d.Name})
+	}
+	return nil
+}
+
+// restoreLocks locks again the restored contexts that were locked when they
+// were deleted.
+func restoreLocks(deleted []deletedContext) error {
+	var relock []string
+	for _, d := range deleted {
+		if d.Locked {
+			relock = append(relock, d.Name)
+		}
+	}
+	if len(relock) == 0 {
+		return nil
+	}
+	locks, err := readLocks()
+	if err != nil {
+		return err
+	}
+	for _, v := range relock {
+		locks, _ = addLock(locks, v)
+	}
+	return writeLocks(locks)
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_readUndo_nonExistingFile(t *testing.T) {
+	v, err := readUndo(filepath.FromSlash("/non/existing/file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil {
+		t.Fatalf("expected nil undo entry; got=%v", v)
+	}
+}
+
+func Test_writeUndo(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "undo-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "foo", "undo")
+
+	want := &undoEntry{
+		Renames: []renamePair{{Old: "a", New: "b"}},
+		Deleted: []deletedContext{{Name: "b", Entry: "name: b\n"}},
+	}
+	if err := writeUndo(path, *want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readUndo(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("readUndo() diff=%s", diff)
+	}
+}
+
+func Test_undoRenames(t *testing.T) {
+	batch := []renamePair{{Old: "a", New: "x-a"}, {Old: "b", New: "x-b"}}
+	tests := []struct {
+		name        string
+		existing    []string
+		wantReverts []renamePair
+		wantSkipped []renamePair
+		wantErr     bool
+	}{
+		{
+			name:        "reverts the whole batch",
+			existing:    []string{"x-a", "x-b", "c"},
+			wantReverts: []renamePair{{Old: "x-b", New: "b"}, {Old: "x-a", New: "a"}},
+		},
+		{
+			name:        "skips renames already reverted",
+			existing:    []string{"a", "x-b"},
+			wantReverts: []renamePair{{Old: "x-b", New: "b"}},
+			wantSkipped: []renamePair{{Old: "a", New: "x-a"}},
+		},
+		{
+			name:     "renamed context no longer exists",
+			existing: []string{"x-b"},
+			wantErr:  true,
+		},
+		{
+			name:     "old name was reused",
+			existing: []string{"a", "x-a", "x-b"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists := func(name string) bool {
+				for _, v := range tt.existing {
+					if v == name {
+						return true
+					}
+				}
+				return false
+			}
+			reverts, skipped, err := undoRenames(batch, exists)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("undoRenames() err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantReverts, reverts); diff != "" {
+				t.Errorf("reverts diff=%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantSkipped, skipped); diff != "" {
+				t.Errorf("skipped diff=%s", diff)
+			}
+		})
+	}
+}
@@ -33,12 +33,14 @@ func (_ UnsetOp) Run(_, stderr io.Writer) error {
 		return errors.Wrap(err, "kubeconfig error")
 	}
 
+	cur := kc.GetCurrentContext()
 	if err := kc.UnsetCurrentContext(); err != nil {
 		return errors.Wrap(err, "error while modifying current-context")
 	}
 	if err := kc.Save(); err != nil {
 		return errors.Wrap(err, "failed to save kubeconfig file after modification")
 	}
+	audit(stderr, auditEntry{Operation: auditUnset, Target: cur})
 
 	err := printer.Success(stderr, "Active context unset for kubectl.")
 	return errors.Wrap(err, "write error")
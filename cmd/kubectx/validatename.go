@@ -0,0 +1,46 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// ValidateNameOp indicates intention to check if a name can be used for a
+// new context, with the rules used when renaming contexts.
+type ValidateNameOp struct {
+	Name string
+}
+
+func (op ValidateNameOp) Run(_, stderr io.Writer) error {
+	if err := validateName(op.Name); err != nil {
+		return errors.Wrapf(err, "invalid context name \"%s\"", op.Name)
+	}
+
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil && !cmdutil.IsNotFoundErr(err) {
+		return errors.Wrap(err, "kubeconfig error")
+	} else if err == nil && kc.ContextExists(op.Name) {
+		return errors.Errorf("context \"%s\" already exists", op.Name)
+	}
+	return printer.Success(stderr, "\"%s\" is a valid context name.", op.Name)
+}
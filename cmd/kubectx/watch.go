@@ -0,0 +1,123 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// watchInterval is how often the kubeconfig files are checked for changes.
+const watchInterval = 500 * time.Millisecond
+
+// WatchCurrentOp prints the current context, and again whenever it changes,
+// until interrupted.
+type WatchCurrentOp struct {
+	Output string // "" for plain names, or outputJSON for a JSON object per line
+}
+
+// parseWatchCurrentArgs parses the arguments following --watch-current.
+func parseWatchCurrentArgs(argv []string) Op {
+	switch {
+	case len(argv) == 0:
+		return WatchCurrentOp{}
+	case len(argv) == 2 && argv[0] == "-o" && argv[1] == outputJSON:
+		return WatchCurrentOp{Output: outputJSON}
+	}
+	return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+}
+
+// filesState describes the modification time and size of the files, to tell
+// if any of them changed.
+func filesState(paths []string) string {
+	var b strings.Builder
+	for _, p := range paths {
+		if fi, err := os.Stat(p); err != nil {
+			fmt.Fprintf(&b, "%s:-\n", p)
+		} else {
+			fmt.Fprintf(&b, "%s:%d:%d\n", p, fi.ModTime().UnixNano(), fi.Size())
+		}
+	}
+	return b.String()
+}
+
+// watchCurrent prints the current context returned by current when it
+// changes, checking the files for changes every interval, until stop
+// receives. Failures to read the current context, like while a file is being
+// rewritten, are ignored until the files change again.
+func watchCurrent(stdout io.Writer, output string, paths []string, interval time.Duration,
+	stop <-chan os.Signal, current func() (string, error)) error {
+	emit := func(ctx string) error {
+		if output == outputJSON {
+			return json.NewEncoder(stdout).Encode(struct {
+				Context string `json:"context"`
+			}{ctx})
+		}
+		_, err := fmt.Fprintln(stdout, ctx)
+		return err
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	var state, last string
+	first := true
+	for {
+		if s := filesState(paths); s != state {
+			state = s
+			if ctx, err := current(); err == nil && (first || ctx != last) {
+				if err := emit(ctx); err != nil {
+					return errors.Wrap(err, "write error")
+				}
+				first, last = false, ctx
+			}
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// readCurrentContext returns the current-context in the kubeconfig files.
+func readCurrentContext() (string, error) {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return "", err
+	}
+	return kc.GetCurrentContext(), nil
+}
+
+func (op WatchCurrentOp) Run(stdout, _ io.Writer) error {
+	paths, err := kubeconfig.Paths()
+	if err != nil {
+		return errors.Wrap(err, "cannot determine kubeconfig path")
+	}
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+	return watchCurrent(stdout, op.Output, paths, watchInterval, stop, readCurrentContext)
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+func Test_watchCurrent(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "watch-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config")
+	write := func(v string) {
+		if err := ioutil.WriteFile(path, []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	current := func() (string, error) {
+		b, err := ioutil.ReadFile(path)
+		return string(b), err
+	}
+	waitFor := func(out *syncBuffer, want string) {
+		deadline := time.Now().Add(5 * time.Second)
+		for out.String() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("output=%q, want=%q", out.String(), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	tests := []struct {
+		output string
+		want   []string
+	}{
+		{output: "", want: []string{"a\n", "bb\n"}},
+		{output: outputJSON, want: []string{`{"context":"a"}` + "\n", `{"context":"bb"}` + "\n"}},
+	}
+	for _, tt := range tests {
+		write("a")
+		var out syncBuffer
+		stop := make(chan os.Signal)
+		done := make(chan error)
+		go func() { done <- watchCurrent(&out, tt.output, []string{path}, time.Millisecond, stop, current) }()
+
+		waitFor(&out, tt.want[0])
+		os.Remove(path) // failing reads are ignored
+		time.Sleep(10 * time.Millisecond)
+		write("a") // same context isn't printed again
+		time.Sleep(10 * time.Millisecond)
+		write("bb")
+		waitFor(&out, strings.Join(tt.want, ""))
+
+		stop <- os.Interrupt
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// WhereOp prints the path of the kubeconfig file defining a context.
+type WhereOp struct {
+	Context string // NAME, or "" for current-context
+}
+
+func (op WhereOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	name := op.Context
+	if name == "" {
+		name = kc.GetCurrentContext()
+		if name == "" {
+			return errors.New("current-context is not set")
+		}
+	}
+	if !kc.ContextExists(name) {
+		return errors.Errorf("no context exists with the name: \"%s\"", name)
+	}
+
+	path, err := kc.ContextSource(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to determine the file defining context \"%s\"", name)
+	}
+	_, err = fmt.Fprintln(stdout, path)
+	return errors.Wrap(err, "write error")
+}
+
+// WhichConfigOp prints the paths of the kubeconfig files in use, in the
+// order of precedence.
+type WhichConfigOp struct{}
+
+func (_ WhichConfigOp) Run(stdout, stderr io.Writer) error {
+	paths, err := kubeconfig.Paths()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine kubeconfig paths")
+	}
+	for _, p := range paths {
+		if _, err := fmt.Fprintln(stdout, p); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+		if _, err := os.Stat(p); os.IsNotExist(err) {
+			printer.Warning(stderr, "kubeconfig file %s does not exist", p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestWhichConfigOp(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.yaml"), filepath.Join(dir, "missing.yaml")
+	if err := os.WriteFile(a, []byte(testutil.KC().ToYAML(t)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer testutil.WithEnvVar("KUBECONFIG", strings.Join([]string{a, b, a}, string(filepath.ListSeparator)))()
+
+	var stdout, stderr bytes.Buffer
+	if err := (WhichConfigOp{}).Run(&stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if want := a + "\n" + b + "\n"; stdout.String() != want {
+		t.Errorf("stdout=%q; want=%q", stdout.String(), want)
+	}
+	if !strings.Contains(stderr.String(), b) || strings.Contains(stderr.String(), a) {
+		t.Errorf("stderr=%q; want a warning only about %s", stderr.String(), b)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// bookmarksFirstFlag is the hidden list flag used by the interactive mode to
+// list the bookmarked namespaces first.
+const bookmarksFirstFlag = "--bookmarks-first"
+
+// BookmarkOp indicates intention to bookmark a namespace of the current
+// context.
+type BookmarkOp struct {
+	Namespace string
+}
+
+// UnbookmarkOp indicates intention to remove the bookmark of a namespace of
+// the current context.
+type UnbookmarkOp struct {
+	Namespace string
+}
+
+// ListBookmarksOp indicates intention to list the bookmarked namespaces of
+// the current context.
+type ListBookmarksOp struct{}
+
+// currentContext loads the kubeconfig and returns it with the name of its
+// current context.
+func currentContext() (*kubeconfig.Kubeconfig, string, error) {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	if err := kc.Parse(); err != nil {
+		kc.Close()
+		return nil, "", errors.Wrap(err, "kubeconfig error")
+	}
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		kc.Close()
+		return nil, "", errors.New("current-context is not set")
+	}
+	return kc, ctx, nil
+}
+
+func (op BookmarkOp) Run(_, stderr io.Writer) error {
+	kc, ctx, err := currentContext()
+	if err != nil {
+		return err
+	}
+	defer kc.Close()
+
+	ok, err := namespace.Exists(kc, ctx, op.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to query namespace")
+	} else if !ok {
+		return withSuggestions(kc, ctx, namespace.NotFoundError{Namespace: op.Namespace})
+	}
+	added, err := namespace.NewBookmarkFile(ctx).Add(op.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to save bookmarks")
+	}
+	if !added {
+		printer.Warning(stderr, "namespace \"%s\" is already bookmarked", op.Namespace)
+		return nil
+	}
+	err = printer.Success(stderr, "Bookmarked namespace \"%s\" of context \"%s\".",
+		printer.SuccessColor.Sprint(op.Namespace), ctx)
+	return errors.Wrap(err, "print error")
+}
+
+func (op UnbookmarkOp) Run(_, stderr io.Writer) error {
+	kc, ctx, err := currentContext()
+	if err != nil {
+		return err
+	}
+	kc.Close()
+
+	removed, err := namespace.NewBookmarkFile(ctx).Remove(op.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to save bookmarks")
+	}
+	if !removed {
+		return errors.Errorf("namespace \"%s\" is not bookmarked", op.Namespace)
+	}
+	err = printer.Success(stderr, "Removed the bookmark of namespace \"%s\".",
+		printer.SuccessColor.Sprint(op.Namespace))
+	return errors.Wrap(err, "print error")
+}
+
+func (_ ListBookmarksOp) Run(stdout, _ io.Writer) error {
+	kc, ctx, err := currentContext()
+	if err != nil {
+		return err
+	}
+	kc.Close()
+
+	bookmarks, err := namespace.NewBookmarkFile(ctx).Load()
+	if err != nil {
+		return errors.Wrap(err, "failed to read bookmarks")
+	}
+	for _, v := range bookmarks {
+		fmt.Fprintln(stdout, v)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+const (
+	// completeFlag is the hidden flag for completion scripts to list the
+	// namespace names starting with a prefix.
+	completeFlag = "--complete"
+
+	completeCacheTTL = time.Minute
+)
+
+var defaultCompleteCacheDir = filepath.Join(cmdutil.HomeDir(), ".kube", "kubens-complete-cache")
+
+// CompleteOp prints the namespaces of the current context starting with
+// Prefix, one per line, without colors. The namespaces are cached, so
+// completing repeatedly doesn't query the cluster each time.
+type CompleteOp struct {
+	Prefix string
+}
+
+// RefreshCompletionCacheOp indicates intention to query and cache the
+// namespaces for completion ahead of time.
+type RefreshCompletionCacheOp struct {
+	AllContexts bool // refresh the cache of every context, not only the current one
+}
+
+// ClearCacheOp indicates intention to delete the cached namespaces of every
+// context, or only of Context if it's set.
+type ClearCacheOp struct {
+	Context string
+}
+
+// cachedNamespaces is the list of namespaces in a context, as cached.
+type cachedNamespaces struct {
+	Namespaces []string  `json:"namespaces"`
+	Time       time.Time `json:"time"`
+}
+
+// readCachedNamespaces returns the cached namespaces, or nil if there are
+// none newer than the TTL.
+func readCachedNamespaces(path string, now time.Time) []string {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var v cachedNamespaces
+	if err := json.Unmarshal(b, &v); err != nil || now.Sub(v.Time) > completeCacheTTL {
+		return nil
+	}
+	return v.Namespaces
+}
+
+// writeCachedNamespaces saves the namespaces, creating missing parent
+// directories.
+func writeCachedNamespaces(path string, v cachedNamespaces) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// cachedQueryNamespaces is like queryNamespaces, but returns the cached
+// namespaces of the context if they're newer than the TTL.
+func cachedQueryNamespaces(kc *kubeconfig.Kubeconfig, ctx string) ([]string, error) {
+	now := time.Now()
+	path := filepath.Join(defaultCompleteCacheDir, cacheFileName(ctx))
+	if ns := readCachedNamespaces(path, now); ns != nil {
+		return ns, nil
+	}
+	ns, err := queryNamespaces(context.Background(), kc, ctx)
+	if err != nil {
+		return nil, err
+	}
+	_ = writeCachedNamespaces(path, cachedNamespaces{Namespaces: ns, Time: now}) // caching is best effort
+	return ns, nil
+}
+
+func (op RefreshCompletionCacheOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	ctxs := kc.ContextNames()
+	if !op.AllContexts {
+		ctx := kc.GetCurrentContext()
+		if ctx == "" {
+			return errors.New("current-context is not set")
+		}
+		ctxs = []string{ctx}
+	}
+
+	var refreshed int
+	for _, ctx := range ctxs {
+		ns, err := queryNamespaces(context.Background(), kc, ctx)
+		if err == nil {
+			path := filepath.Join(defaultCompleteCacheDir, cacheFileName(ctx))
+			err = writeCachedNamespaces(path, cachedNamespaces{Namespaces: ns, Time: time.Now()})
+		}
+		if err != nil {
+			if !op.AllContexts {
+				return errors.Wrap(err, "failed to refresh the completion cache")
+			}
+			printer.Warning(stderr, "could not refresh the completion cache of context \"%s\": %s", ctx, err)
+			continue
+		}
+		refreshed++
+	}
+	err := printer.Success(stderr, "Refreshed the namespace completion cache of %d contexts.", refreshed)
+	return errors.Wrap(err, "print error")
+}
+
+// parseClearCacheArgs parses the arguments following --clear-cache.
+func parseClearCacheArgs(argv []string) Op {
+	switch {
+	case len(argv) == 0:
+		return ClearCacheOp{}
+	case argv[0] == "--context":
+		if len(argv) != 2 || argv[1] == "" {
+			return UnsupportedOp{Err: fmt.Errorf("'--context' needs a context name")}
+		}
+		return ClearCacheOp{Context: argv[1]}
+	}
+	return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+}
+
+// clearCachedNamespaces deletes the cache files in dir, only the one of ctx if
+// it's set, and returns how many were deleted.
+func clearCachedNamespaces(dir, ctx string) (int, error) {
+	if ctx != "" {
+		err := os.Remove(filepath.Join(dir, cacheFileName(ctx)))
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, f.Name())); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func (op ClearCacheOp) Run(_, stderr io.Writer) error {
+	n, err := clearCachedNamespaces(defaultCompleteCacheDir, op.Context)
+	if err != nil {
+		return errors.Wrap(err, "failed to clear the namespace cache")
+	}
+	if op.Context != "" {
+		if n == 0 {
+			printer.Warning(stderr, "no cached namespaces for context \"%s\"", op.Context)
+			return nil
+		}
+		err = printer.Success(stderr, "Cleared the cached namespaces of context \"%s\".", op.Context)
+		return errors.Wrap(err, "print error")
+	}
+	err = printer.Success(stderr, "Cleared the cached namespaces of %d contexts.", n)
+	return errors.Wrap(err, "print error")
+}
+
+func (op CompleteOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		return errors.New("current-context is not set")
+	}
+
+	ns, err := cachedQueryNamespaces(kc, ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not list namespaces (is the cluster accessible?)")
+	}
+	for _, v := range ns {
+		if !strings.HasPrefix(v, op.Prefix) {
+			continue
+		}
+		if _, err := fmt.Fprintln(stdout, v); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
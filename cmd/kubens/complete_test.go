@@ -0,0 +1,78 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_cachedNamespaces(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "complete-cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sub", cacheFileName("arn:aws:eks:us-east-1:1:cluster/foo"))
+
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	if v := readCachedNamespaces(path, now); v != nil {
+		t.Fatalf("expected no cached namespaces; got=%v", v)
+	}
+
+	want := []string{"default", "kube-system"}
+	if err := writeCachedNamespaces(path, cachedNamespaces{Namespaces: want, Time: now}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, readCachedNamespaces(path, now.Add(completeCacheTTL))); diff != "" {
+		t.Fatalf("readCachedNamespaces() diff=%s", diff)
+	}
+	if v := readCachedNamespaces(path, now.Add(completeCacheTTL+time.Second)); v != nil {
+		t.Fatalf("expected expired cached namespaces to be ignored; got=%v", v)
+	}
+}
+
+func Test_clearCachedNamespaces(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "complete-cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, ctx := range []string{"a", "b", "c"} {
+		err := writeCachedNamespaces(filepath.Join(dir, cacheFileName(ctx)), cachedNamespaces{Namespaces: []string{"default"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n, err := clearCachedNamespaces(dir, "a"); err != nil || n != 1 {
+		t.Fatalf("clearing one context: n=%d err=%v", n, err)
+	}
+	if n, err := clearCachedNamespaces(dir, "a"); err != nil || n != 0 {
+		t.Fatalf("clearing an uncached context: n=%d err=%v", n, err)
+	}
+	if n, err := clearCachedNamespaces(dir, ""); err != nil || n != 2 {
+		t.Fatalf("clearing all contexts: n=%d err=%v", n, err)
+	}
+	if n, err := clearCachedNamespaces(filepath.Join(dir, "missing"), ""); err != nil || n != 0 {
+		t.Fatalf("clearing a missing cache: n=%d err=%v", n, err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// CopyFromOp indicates intention to switch the current context to the
+// namespace another context uses.
+type CopyFromOp struct {
+	Context string // context to copy the namespace of
+}
+
+func (op CopyFromOp) Run(stdout, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	if !kc.ContextExists(op.Context) {
+		return errors.Errorf("no context exists with the name: \"%s\"", op.Context)
+	}
+	ns, err := kc.NamespaceOfContext(op.Context)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read namespace of context \"%s\"", op.Context)
+	}
+	kc.Close()
+
+	// switching checks that the namespace exists in the current cluster
+	return SwitchOp{Target: ns}.Run(stdout, stderr)
+}
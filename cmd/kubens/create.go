@@ -0,0 +1,135 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/ahmetb/kubectx/internal/kubeclient"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// CreateOp indicates intention to create a namespace in the cluster of the
+// current context.
+type CreateOp struct {
+	Name string // namespace to create
+	From string // namespace to copy the labels and annotations of, if set
+}
+
+// parseCreateArgs parses the arguments following --create.
+func parseCreateArgs(argv []string) Op {
+	var op CreateOp
+	for i := 0; i < len(argv); i++ {
+		switch v := argv[i]; {
+		case v == "--from":
+			if i+1 >= len(argv) || argv[i+1] == "" {
+				return UnsupportedOp{Err: fmt.Errorf("'--from' needs a namespace name")}
+			}
+			i++
+			op.From = argv[i]
+		case op.Name == "" && v != "" && v[0] != '-':
+			op.Name = v
+		default:
+			return UnsupportedOp{Err: fmt.Errorf("unsupported argument %q", v)}
+		}
+	}
+	if op.Name == "" {
+		return UnsupportedOp{Err: fmt.Errorf("'--create' needs a namespace name")}
+	}
+	return op
+}
+
+// copiedMetadata tells if a label or annotation of the source namespace is
+// copied to the new one. The ones set by Kubernetes and kubectl for the
+// source namespace itself aren't.
+func copiedMetadata(key string) bool {
+	switch key {
+	case corev1.LabelMetadataName, corev1.LastAppliedConfigAnnotation:
+		return false
+	}
+	return true
+}
+
+func copyMetadata(m map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range m {
+		if !copiedMetadata(k) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// createNamespace creates the namespace, with the labels and annotations of
+// the from namespace if it's set.
+func createNamespace(ctx context.Context, client kubernetes.Interface, name, from string) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if from != "" {
+		src, err := client.CoreV1().Namespaces().Get(ctx, from, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return errors.Errorf("namespace \"%s\" to copy from not found", from)
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to get namespace \"%s\"", from)
+		}
+		ns.Labels = copyMetadata(src.Labels)
+		ns.Annotations = copyMetadata(src.Annotations)
+	}
+	_, err := client.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return errors.Errorf("namespace \"%s\" already exists", name)
+	}
+	return errors.Wrapf(err, "failed to create namespace \"%s\"", name)
+}
+
+func (op CreateOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		return errors.New("current-context is not set")
+	}
+
+	if os.Getenv("_MOCK_NAMESPACES") == "" {
+		clientset, err := kubeclient.NewClientSet(kc, ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to initialize k8s REST client")
+		}
+		if err := createNamespace(context.Background(), clientset, op.Name, op.From); err != nil {
+			return err
+		}
+	}
+	if op.From != "" {
+		return printer.Success(stderr, "Created namespace \"%s\" with the labels and annotations of \"%s\"",
+			printer.SuccessColor.Sprint(op.Name), op.From)
+	}
+	return printer.Success(stderr, "Created namespace \"%s\"", printer.SuccessColor.Sprint(op.Name))
+}
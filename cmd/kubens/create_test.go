@@ -0,0 +1,64 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_createNamespace(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "team-a",
+		Labels: map[string]string{
+			"team":                   "a",
+			corev1.LabelMetadataName: "team-a",
+		},
+		Annotations: map[string]string{
+			"owner":                            "alice",
+			corev1.LastAppliedConfigAnnotation: "{}",
+		},
+	}})
+	ctx := context.Background()
+
+	if err := createNamespace(ctx, client, "team-b", "team-a"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.CoreV1().Namespaces().Get(ctx, "team-b", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"team": "a"}, got.Labels); diff != "" {
+		t.Errorf("labels diff=%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"owner": "alice"}, got.Annotations); diff != "" {
+		t.Errorf("annotations diff=%s", diff)
+	}
+
+	if err := createNamespace(ctx, client, "team-c", "missing"); err == nil {
+		t.Error("expected error for missing source namespace")
+	}
+	if _, err := client.CoreV1().Namespaces().Get(ctx, "team-c", metav1.GetOptions{}); err == nil {
+		t.Error("namespace was created despite the missing source namespace")
+	}
+	if err := createNamespace(ctx, client, "team-b", ""); err == nil {
+		t.Error("expected error for existing namespace")
+	}
+}
@@ -17,13 +17,103 @@ package main
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/ahmetb/kubectx/internal/kubeconfig"
 )
 
-type CurrentOp struct{}
+type CurrentOp struct {
+	Context string // context to show the namespace of, or "" for current-context
+	Server  string // pick the context by its cluster's server address instead, if set
+	Output  string // output format, "" for the namespace name or "json"
+}
+
+// currentNamespace is the namespace of a context, as printed with -o json.
+type currentNamespace struct {
+	Context   string `json:"context"`
+	Namespace string `json:"namespace"`
+}
+
+// parseContextArgs parses the --context <NAME> (or --server <URL>) flag along
+// with either -c/--current (and its -o json flag), or a namespace to switch to
+// in that context, in any order.
+func parseContextArgs(argv []string) Op {
+	var context, server, output string
+	flag := "--context"
+	var current, force bool
+	var positional []string
+	for i := 0; i < len(argv); i++ {
+		switch v := argv[i]; v {
+		case "-c", "--current":
+			current = true
+		case "-f", "--force":
+			force = true
+		case "--context":
+			if i+1 >= len(argv) {
+				return UnsupportedOp{Err: fmt.Errorf("'--context' needs an argument")}
+			}
+			i++
+			context = argv[i]
+		case "--server":
+			if i+1 >= len(argv) || argv[i+1] == "" {
+				return UnsupportedOp{Err: fmt.Errorf("'--server' needs a server address")}
+			}
+			i++
+			server, flag = argv[i], v
+		case "--json":
+			output = outputJSON
+		case "-o", "--output":
+			if i+1 >= len(argv) {
+				return UnsupportedOp{Err: fmt.Errorf("'%s' needs an argument", v)}
+			}
+			i++
+			if argv[i] != outputJSON {
+				return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[i])}
+			}
+			output = argv[i]
+		default:
+			if strings.HasPrefix(v, "-") && v != "-" {
+				return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+			}
+			positional = append(positional, v)
+		}
+	}
+	if context != "" && server != "" {
+		return UnsupportedOp{Err: fmt.Errorf("'--context' and '--server' can't be combined")}
+	}
+	if current {
+		if force || len(positional) > 0 {
+			return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+		}
+		return CurrentOp{Context: context, Server: server, Output: output}
+	}
+	if output != "" {
+		return UnsupportedOp{Err: fmt.Errorf("'-o %s' needs '-c/--current'", output)}
+	}
+	if len(positional) != 1 {
+		return UnsupportedOp{Err: fmt.Errorf("'%s' needs '-c/--current' or a namespace name", flag)}
+	}
+	return SwitchOp{Target: positional[0], Force: force, Context: context, Server: server}
+}
+
+// contextByServer returns the context whose cluster has the server address.
+// It fails if no context, or more than one, has it.
+func contextByServer(kc *kubeconfig.Kubeconfig, server string) (string, error) {
+	ctxs, err := kc.ContextsWithServer(server)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read the clusters of the contexts")
+	}
+	switch len(ctxs) {
+	case 0:
+		return "", errors.Errorf("no context has a cluster with server \"%s\"", server)
+	case 1:
+		return ctxs[0], nil
+	}
+	return "", errors.Errorf("server \"%s\" is used by multiple contexts (%s), use --context instead",
+		server, strings.Join(ctxs, ", "))
+}
 
 func (c CurrentOp) Run(stdout, _ io.Writer) error {
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
@@ -32,14 +122,27 @@ func (c CurrentOp) Run(stdout, _ io.Writer) error {
 		return errors.Wrap(err, "kubeconfig error")
 	}
 
-	ctx := kc.GetCurrentContext()
-	if ctx == "" {
-		return errors.New("current-context is not set")
+	ctx := c.Context
+	if c.Server != "" {
+		var err error
+		if ctx, err = contextByServer(kc, c.Server); err != nil {
+			return err
+		}
+	} else if ctx == "" {
+		ctx = kc.GetCurrentContext()
+		if ctx == "" {
+			return errors.New("current-context is not set")
+		}
+	} else if !kc.ContextExists(ctx) {
+		return errors.Errorf("no context exists with the name: \"%s\"", ctx)
 	}
 	ns, err := kc.NamespaceOfContext(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "failed to read namespace of \"%s\"", ctx)
 	}
+	if c.Output == outputJSON {
+		return writeJSON(stdout, currentNamespace{Context: ctx, Namespace: ns})
+	}
 	_, err = fmt.Fprintln(stdout, ns)
 	return errors.Wrap(err, "write error")
 }
@@ -0,0 +1,77 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestCurrentOp_server(t *testing.T) {
+	path, cleanup := testutil.TempFile(t, `contexts:
+- name: a
+  context: {cluster: shared}
+- name: b
+  context: {cluster: shared, namespace: kube-system}
+- name: c
+  context: {cluster: own, namespace: team-c}
+clusters:
+- name: shared
+  cluster: {server: "https://shared.example.com"}
+- name: own
+  cluster: {server: "https://own.example.com"}
+current-context: a`)
+	defer cleanup()
+	defer testutil.WithEnvVar("KUBECONFIG", path)()
+
+	var stdout bytes.Buffer
+	if err := (CurrentOp{Server: "https://own.example.com/"}).Run(&stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := stdout.String(); got != "team-c\n" {
+		t.Fatalf("namespace=%q; want=team-c", got)
+	}
+
+	err := CurrentOp{Server: "https://shared.example.com"}.Run(&stdout, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "multiple contexts (a, b)") {
+		t.Fatalf("err=%v; want an error for the ambiguous server", err)
+	}
+	if err := (CurrentOp{Server: "https://missing.example.com"}).Run(&stdout, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for unknown server")
+	}
+}
+
+func TestCurrentOp_json(t *testing.T) {
+	path, cleanup := testutil.TempFile(t, `contexts:
+- name: a
+  context: {cluster: x}
+- name: b
+  context: {cluster: x, namespace: team-b}
+current-context: a`)
+	defer cleanup()
+	defer testutil.WithEnvVar("KUBECONFIG", path)()
+
+	var stdout bytes.Buffer
+	if err := (CurrentOp{Context: "b", Output: outputJSON}).Run(&stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\n  \"context\": \"b\",\n  \"namespace\": \"team-b\"\n}\n"
+	if got := stdout.String(); got != want {
+		t.Fatalf("output=%q; want=%q", got, want)
+	}
+}
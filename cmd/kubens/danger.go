@@ -0,0 +1,56 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/namespace"
+)
+
+// confirmSwitch asks to confirm switching to the namespace of the context if
+// its name matches the danger pattern. There's nothing to confirm if force
+// is set or the user can't be asked, like in scripts.
+func confirmSwitch(stderr io.Writer, ctx, ns string, force bool) error {
+	if force || !cmdutil.CanPrompt() {
+		return nil
+	}
+	if ns == "-" {
+		prev, err := namespace.NewNSFile(ctx).Load()
+		if err != nil || prev == "" {
+			return nil // reported when switching
+		}
+		ns = prev
+	}
+	re, err := cmdutil.NamespaceDangerPattern()
+	if err != nil {
+		return err
+	}
+	if re == nil || !re.MatchString(ns) {
+		return nil
+	}
+	ok, err := cmdutil.ConfirmDangerous(os.Stdin, stderr, "namespace", ns)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("not switching to namespace \"%s\"", ns)
+	}
+	return nil
+}
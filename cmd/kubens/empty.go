@@ -0,0 +1,252 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeclient"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+const (
+	// emptyPods considers namespaces without pods empty.
+	emptyPods = "pods"
+	// emptyAll considers namespaces without any of the common workload,
+	// service and volume claim resources empty.
+	emptyAll = "all"
+
+	defaultEmptyTimeout     = 10 * time.Second
+	defaultEmptyConcurrency = 8
+)
+
+// ListEmptyOp indicates intention to list the namespaces of the current
+// context that have no resources, as candidates for deletion.
+type ListEmptyOp struct {
+	Resources string        // emptiness criterion, emptyPods or emptyAll
+	Timeout   time.Duration // timeout of the queries of each namespace
+	Output    string        // output format, "" for plain text or "json"
+}
+
+// parseListEmptyArgs parses the arguments following --list-empty.
+func parseListEmptyArgs(argv []string) Op {
+	op := ListEmptyOp{Resources: emptyPods, Timeout: defaultEmptyTimeout}
+	for i := 0; i < len(argv); i++ {
+		v := argv[i]
+		switch v {
+		case "--resources", "--timeout", "-o", "--output":
+			if i+1 >= len(argv) {
+				return UnsupportedOp{Err: fmt.Errorf("'%s' needs an argument", v)}
+			}
+			i++
+		default:
+			return UnsupportedOp{Err: fmt.Errorf("unsupported option '%s'", v)}
+		}
+		switch v {
+		case "--resources":
+			if argv[i] != emptyPods && argv[i] != emptyAll {
+				return UnsupportedOp{Err: fmt.Errorf("'--resources' must be %q or %q", emptyPods, emptyAll)}
+			}
+			op.Resources = argv[i]
+		case "--timeout":
+			d, err := time.ParseDuration(argv[i])
+			if err != nil || d <= 0 {
+				return UnsupportedOp{Err: fmt.Errorf("invalid timeout %q", argv[i])}
+			}
+			op.Timeout = d
+		case "-o", "--output":
+			if argv[i] != outputJSON {
+				return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[i])}
+			}
+			op.Output = argv[i]
+		}
+	}
+	return op
+}
+
+// emptyCheck determines if a namespace is empty.
+type emptyCheck func(ctx context.Context, ns string) (bool, error)
+
+// emptyResult is the outcome of checking a namespace.
+type emptyResult struct {
+	Namespace string
+	Empty     bool
+	Err       error
+}
+
+// checkEmpty runs the check for each namespace, at most concurrency at a
+// time, and returns the results in the order of namespaces.
+func checkEmpty(namespaces []string, concurrency int, timeout time.Duration, check emptyCheck) []emptyResult {
+	out := make([]emptyResult, len(namespaces))
+	cmdutil.ForEachWithTimeout(len(namespaces), concurrency, timeout, func(ctx context.Context, i int) {
+		empty, err := check(ctx, namespaces[i])
+		out[i] = emptyResult{Namespace: namespaces[i], Empty: empty, Err: err}
+	})
+	return out
+}
+
+// resourceLister lists at most one resource of a kind in a namespace and
+// returns the number found.
+type resourceLister struct {
+	kind string
+	list func(ctx context.Context, ns string) (int, error)
+}
+
+// resourceListers returns the listers of the resource kinds the criterion
+// looks at, pods first.
+func resourceListers(clientset *kubernetes.Clientset, resources string) []resourceLister {
+	opts := metav1.ListOptions{Limit: 1}
+	out := []resourceLister{
+		{"pods", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.CoreV1().Pods(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+	}
+	if resources != emptyAll {
+		return out
+	}
+	return append(out, []resourceLister{
+		{"deployments", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.AppsV1().Deployments(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+		{"statefulsets", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.AppsV1().StatefulSets(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+		{"daemonsets", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.AppsV1().DaemonSets(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+		{"jobs", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.BatchV1().Jobs(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+		{"cronjobs", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.BatchV1().CronJobs(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+		{"services", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.CoreV1().Services(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+		{"persistentvolumeclaims", func(ctx context.Context, ns string) (int, error) {
+			v, err := clientset.CoreV1().PersistentVolumeClaims(ns).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(v.Items), nil
+		}},
+	}...)
+}
+
+// clusterEmptyCheck returns a check listing the resources of the criterion
+// in the cluster of the context. Config maps and secrets aren't looked at,
+// as Kubernetes creates some in every namespace.
+func clusterEmptyCheck(kc *kubeconfig.Kubeconfig, kctx, resources string) (emptyCheck, error) {
+	if os.Getenv("_MOCK_NAMESPACES") != "" {
+		return func(_ context.Context, ns string) (bool, error) { return ns == "ns2", nil }, nil
+	}
+	clientset, err := kubeclient.NewClientSet(kc, kctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize k8s REST client")
+	}
+	listers := resourceListers(clientset, resources)
+	return func(ctx context.Context, ns string) (bool, error) {
+		for _, l := range listers {
+			n, err := l.list(ctx, ns)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to list %s", l.kind)
+			}
+			if n > 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	}, nil
+}
+
+func (op ListEmptyOp) Run(stdout, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	kctx := kc.GetCurrentContext()
+	if kctx == "" {
+		return errors.New("current-context is not set")
+	}
+
+	namespaces, err := queryNamespaces(context.Background(), kc, kctx)
+	if err != nil {
+		return errors.Wrap(err, "could not list namespaces (is the cluster accessible?)")
+	}
+	check, err := clusterEmptyCheck(kc, kctx, op.Resources)
+	if err != nil {
+		return err
+	}
+
+	empty := []string{}
+	for _, r := range checkEmpty(namespaces, defaultEmptyConcurrency, op.Timeout, check) {
+		if r.Err != nil {
+			printer.Warning(stderr, "could not check namespace \"%s\": %v", r.Namespace, r.Err)
+			continue
+		}
+		if r.Empty {
+			empty = append(empty, r.Namespace)
+		}
+	}
+
+	if op.Output == outputJSON {
+		return writeJSON(stdout, contextNamespaces{Context: kctx, Namespaces: empty})
+	}
+	for _, ns := range empty {
+		if _, err := fmt.Fprintln(stdout, ns); err != nil {
+			return errors.Wrap(err, "write error")
+		}
+	}
+	return nil
+}
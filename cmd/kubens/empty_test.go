@@ -0,0 +1,61 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_checkEmpty(t *testing.T) {
+	var running, maxRunning int32
+	check := func(ctx context.Context, ns string) (bool, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		switch ns {
+		case "empty", "empty2":
+			return true, nil
+		case "slow":
+			<-ctx.Done()
+			return false, ctx.Err()
+		}
+		return false, nil
+	}
+
+	got := checkEmpty([]string{"used", "empty", "slow", "empty2"}, 2, 10*time.Millisecond, check)
+	want := []emptyResult{
+		{Namespace: "used"},
+		{Namespace: "empty", Empty: true},
+		{Namespace: "slow", Err: context.DeadlineExceeded},
+		{Namespace: "empty2", Empty: true},
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b error) bool { return errors.Is(a, b) })); diff != "" {
+		t.Fatalf("diff=%s", diff)
+	}
+	if maxRunning > 2 {
+		t.Fatalf("expected at most 2 concurrent checks; got=%d", maxRunning)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/namespace"
+)
+
+// ExecOp indicates intention to run a command with a kubeconfig that only
+// has the current context, with the namespace set, without changing the
+// active namespace.
+type ExecOp struct {
+	Namespace string
+	Command   []string // the command and its arguments
+}
+
+// parseExecArgs parses the arguments following --exec.
+func parseExecArgs(argv []string) Op {
+	if len(argv) < 3 || argv[1] != "--" {
+		return UnsupportedOp{Err: fmt.Errorf("'--exec' needs a namespace name, '--' and a command")}
+	}
+	return ExecOp{Namespace: argv[0], Command: argv[2:]}
+}
+
+func (op ExecOp) Run(stdout, stderr io.Writer) error {
+	kc, ctx, err := currentContext()
+	if err != nil {
+		return err
+	}
+	defer kc.Close()
+
+	ok, err := namespace.Exists(kc, ctx, op.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to query if namespace exists (is cluster accessible?)")
+	} else if !ok {
+		return withSuggestions(kc, ctx, namespace.NotFoundError{Namespace: op.Namespace})
+	}
+	// only changed in memory, the kubeconfig is never saved
+	if err := kc.SetNamespace(ctx, op.Namespace); err != nil {
+		return errors.Wrap(err, "failed to set namespace")
+	}
+	b, err := kc.Minify(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to extract context \"%s\"", ctx)
+	}
+	return cmdutil.RunWithKubeconfig(stdout, stderr, b, "kubens-exec-*.yaml", op.Command)
+}
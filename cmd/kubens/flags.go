@@ -43,6 +43,87 @@ func parseArgs(argv []string) Op {
 		return ListOp{}
 	}
 
+	// the arguments of the command to run may contain any flag
+	if argv[0] == "--exec" {
+		return parseExecArgs(argv[1:])
+	}
+	if args, preview := cmdutil.StripFlag(argv, "--preview"); preview {
+		return parsePreviewArgs(args)
+	}
+	if argv[0] == describeFlag && n == 2 {
+		return DescribeOp{Namespace: argv[1]}
+	}
+	if argv[0] == completeFlag {
+		if n > 2 {
+			return UnsupportedOp{Err: fmt.Errorf("'%s' takes at most one prefix", completeFlag)}
+		}
+		var op CompleteOp
+		if n == 2 {
+			op.Prefix = argv[1]
+		}
+		return op
+	}
+	if argv[0] == "--clear-cache" {
+		return parseClearCacheArgs(argv[1:])
+	}
+
+	if op, ok := parseListArgs(argv); ok {
+		// only sorting the list, pick from it interactively
+		if l, isList := op.(ListOp); isList && l.onlyOrdering() && cmdutil.IsInteractiveMode(os.Stdout) {
+			return InteractiveSwitchOp{SelfCmd: os.Args[0], Sort: l.Sort, MaxResults: l.MaxResults}
+		}
+		return op
+	}
+
+	if n > 1 && (slices.Contains([]string{"-c", "--current"}, argv[0]) || slices.Contains(argv, "--context") || slices.Contains(argv, "--server") ||
+		(slices.Contains([]string{"-o", "--output", "--json"}, argv[0]) && (slices.Contains(argv, "-c") || slices.Contains(argv, "--current")))) {
+		return parseContextArgs(argv)
+	}
+
+	if argv[0] == "--copy-from" {
+		if n != 2 || argv[1] == "" {
+			return UnsupportedOp{Err: fmt.Errorf("'--copy-from' needs a context name")}
+		}
+		return CopyFromOp{Context: argv[1]}
+	}
+	if argv[0] == "--create" {
+		return parseCreateArgs(argv[1:])
+	}
+	if n == 2 && argv[0] == "--bookmark" {
+		return BookmarkOp{Namespace: argv[1]}
+	}
+	if n == 2 && argv[0] == "--unbookmark" {
+		return UnbookmarkOp{Namespace: argv[1]}
+	}
+	if argv[0] == "--require" {
+		if n != 2 || argv[1] == "" {
+			return UnsupportedOp{Err: fmt.Errorf("'--require' needs a namespace name or pattern")}
+		}
+		return RequireOp{Namespace: argv[1]}
+	}
+
+	if argv[0] == "--refresh-completion-cache" {
+		switch {
+		case n == 1:
+			return RefreshCompletionCacheOp{}
+		case n == 2 && (argv[1] == "-A" || argv[1] == "--all-contexts"):
+			return RefreshCompletionCacheOp{AllContexts: true}
+		}
+		return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+	}
+
+	if argv[0] == "--list-empty" {
+		return parseListEmptyArgs(argv[1:])
+	}
+
+	if argv[0] == "--stats" {
+		return parseStatsArgs(argv[1:])
+	}
+
+	if argv[0] == "--print-env" {
+		return parsePrintEnvArgs(argv[1:])
+	}
+
 	if n == 1 {
 		v := argv[0]
 		switch v {
@@ -52,6 +133,16 @@ func parseArgs(argv []string) Op {
 			return VersionOp{}
 		case "--current", "-c":
 			return CurrentOp{}
+		case "--pin":
+			return PinOp{}
+		case "--unpin":
+			return UnpinOp{}
+		case "--reset-stats":
+			return ResetStatsOp{}
+		case "--bookmarks":
+			return ListBookmarksOp{}
+		case "--peek":
+			return PeekOp{}
 		default:
 			return getSwitchOp(v, false)
 		}
@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -45,9 +46,225 @@ func Test_parseArgs_new(t *testing.T) {
 		{name: "current long form",
 			args: []string{"--current"},
 			want: CurrentOp{}},
+		{name: "current of another context",
+			args: []string{"-c", "--context", "foo"},
+			want: CurrentOp{Context: "foo"}},
+		{name: "current of another context flag first",
+			args: []string{"--context", "foo", "--current"},
+			want: CurrentOp{Context: "foo"}},
+		{name: "context without current or namespace",
+			args: []string{"--context", "foo"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--context' needs '-c/--current' or a namespace name")}},
+		{name: "switch in another context",
+			args: []string{"--context", "foo", "bar"},
+			want: SwitchOp{Target: "bar", Context: "foo"}},
+		{name: "force switch in another context",
+			args: []string{"bar", "-f", "--context", "foo"},
+			want: SwitchOp{Target: "bar", Force: true, Context: "foo"}},
+		{name: "current with namespace",
+			args: []string{"-c", "--context", "foo", "bar"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", []string{"-c", "--context", "foo", "bar"})}},
+		{name: "current of context by server",
+			args: []string{"-c", "--server", "https://1.2.3.4"},
+			want: CurrentOp{Server: "https://1.2.3.4"}},
+		{name: "switch in context by server",
+			args: []string{"--server", "https://1.2.3.4", "bar"},
+			want: SwitchOp{Target: "bar", Server: "https://1.2.3.4"}},
+		{name: "server without current or namespace",
+			args: []string{"--server", "https://1.2.3.4"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--server' needs '-c/--current' or a namespace name")}},
+		{name: "server with context",
+			args: []string{"--server", "https://1.2.3.4", "--context", "foo", "bar"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--context' and '--server' can't be combined")}},
+		{name: "current as json",
+			args: []string{"-c", "-o", "json"},
+			want: CurrentOp{Output: "json"}},
+		{name: "current of another context as json",
+			args: []string{"--context", "foo", "-c", "-o", "json"},
+			want: CurrentOp{Context: "foo", Output: "json"}},
+		{name: "current as json flag first",
+			args: []string{"-o", "json", "--current"},
+			want: CurrentOp{Output: "json"}},
+		{name: "current with unsupported output",
+			args: []string{"-c", "-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", "yaml")}},
+		{name: "switch in another context with output",
+			args: []string{"--context", "foo", "bar", "-o", "json"},
+			want: UnsupportedOp{Err: fmt.Errorf("'-o json' needs '-c/--current'")}},
+		{name: "current with context missing name",
+			args: []string{"-c", "--context"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--context' needs an argument")}},
+		{name: "pin",
+			args: []string{"--pin"},
+			want: PinOp{}},
+		{name: "unpin",
+			args: []string{"--unpin"},
+			want: UnpinOp{}},
+		{name: "bookmark",
+			args: []string{"--bookmark", "ns1"},
+			want: BookmarkOp{Namespace: "ns1"}},
+		{name: "unbookmark",
+			args: []string{"--unbookmark", "ns1"},
+			want: UnbookmarkOp{Namespace: "ns1"}},
+		{name: "list bookmarks",
+			args: []string{"--bookmarks"},
+			want: ListBookmarksOp{}},
+		{name: "list bookmarks first",
+			args: []string{"--bookmarks-first", "--sort"},
+			want: ListOp{Sort: "recent", BookmarksFirst: true}},
+		{name: "exec",
+			args: []string{"--exec", "ns1", "--", "kubectl", "get", "pods", "-f"},
+			want: ExecOp{Namespace: "ns1", Command: []string{"kubectl", "get", "pods", "-f"}}},
+		{name: "exec with flags of kubens in the command",
+			args: []string{"--exec", "ns1", "--", "echo", "hi", "--context", "prod", "--preview"},
+			want: ExecOp{Namespace: "ns1", Command: []string{"echo", "hi", "--context", "prod", "--preview"}}},
+		{name: "exec without separator",
+			args: []string{"--exec", "ns1", "kubectl"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--exec' needs a namespace name, '--' and a command")}},
+		{name: "refresh completion cache",
+			args: []string{"--refresh-completion-cache"},
+			want: RefreshCompletionCacheOp{}},
+		{name: "refresh completion cache of every context",
+			args: []string{"--refresh-completion-cache", "-A"},
+			want: RefreshCompletionCacheOp{AllContexts: true}},
+		{name: "clear cache",
+			args: []string{"--clear-cache"},
+			want: ClearCacheOp{}},
+		{name: "clear cache of context",
+			args: []string{"--clear-cache", "--context", "prod"},
+			want: ClearCacheOp{Context: "prod"}},
+		{name: "clear cache of context missing name",
+			args: []string{"--clear-cache", "--context"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--context' needs a context name")}},
+		{name: "require namespace",
+			args: []string{"--require", "kube-*"},
+			want: RequireOp{Namespace: "kube-*"}},
+		{name: "require without pattern",
+			args: []string{"--require"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--require' needs a namespace name or pattern")}},
+		{name: "list empty namespaces",
+			args: []string{"--list-empty"},
+			want: ListEmptyOp{Resources: emptyPods, Timeout: defaultEmptyTimeout}},
+		{name: "list empty namespaces with options",
+			args: []string{"--list-empty", "--resources", "all", "--timeout", "3s", "-o", "json"},
+			want: ListEmptyOp{Resources: emptyAll, Timeout: 3 * time.Second, Output: outputJSON}},
+		{name: "list empty namespaces with unknown criterion",
+			args: []string{"--list-empty", "--resources", "configmaps"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--resources' must be \"pods\" or \"all\"")}},
+		{name: "list empty namespaces with missing argument",
+			args: []string{"--list-empty", "--timeout"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--timeout' needs an argument")}},
+		{name: "copy namespace from context",
+			args: []string{"--copy-from", "staging"},
+			want: CopyFromOp{Context: "staging"}},
+		{name: "copy namespace without context",
+			args: []string{"--copy-from"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--copy-from' needs a context name")}},
+		{name: "create namespace",
+			args: []string{"--create", "team-b"},
+			want: CreateOp{Name: "team-b"}},
+		{name: "create namespace from another",
+			args: []string{"--create", "team-b", "--from", "team-a"},
+			want: CreateOp{Name: "team-b", From: "team-a"}},
+		{name: "create namespace without name",
+			args: []string{"--create", "--from", "team-a"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--create' needs a namespace name")}},
+		{name: "create namespace from without name",
+			args: []string{"--create", "team-b", "--from"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--from' needs a namespace name")}},
+		{name: "peek previous namespace",
+			args: []string{"--peek"},
+			want: PeekOp{}},
+		{name: "stats",
+			args: []string{"--stats"},
+			want: StatsOp{}},
+		{name: "stats in json",
+			args: []string{"--stats", "-o", "json"},
+			want: StatsOp{Output: "json"}},
+		{name: "stats in unsupported format",
+			args: []string{"--stats", "-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", "yaml")}},
+		{name: "reset stats",
+			args: []string{"--reset-stats"},
+			want: ResetStatsOp{}},
+		{name: "list recent first in non-interactive mode",
+			args: []string{"--sort"},
+			want: ListOp{Sort: "recent"}},
+		{name: "list sorted by name in every context",
+			args: []string{"-A", "--sort=name"},
+			want: ListOp{AllContexts: true, Sort: "name"}},
+		{name: "list in unsupported order",
+			args: []string{"--sort=foo"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported sort order %q", "foo")}},
+		{name: "preview in non-interactive mode",
+			args: []string{"--preview"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--preview' needs interactive mode (fzf installed and a terminal)")}},
+		{name: "preview with non-sort flags",
+			args: []string{"--preview", "-A"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--preview' can only be combined with '--sort' and '--max-results'")}},
+		{name: "describe namespace",
+			args: []string{"--describe-namespace", "foo"},
+			want: DescribeOp{Namespace: "foo"}},
+		{name: "complete prefix",
+			args: []string{"--complete", "kube-"},
+			want: CompleteOp{Prefix: "kube-"}},
+		{name: "complete too many prefixes",
+			args: []string{"--complete", "a", "b"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--complete' takes at most one prefix")}},
+		{name: "list all contexts shorthand",
+			args: []string{"-A"},
+			want: ListOp{AllContexts: true}},
+		{name: "list all contexts as json",
+			args: []string{"--json", "--all-contexts"},
+			want: ListOp{AllContexts: true, Output: "json"}},
+		{name: "count namespaces in all contexts",
+			args: []string{"--count", "-A"},
+			want: ListOp{AllContexts: true, Count: true}},
+		{name: "count namespaces as json",
+			args: []string{"--count", "-A", "-o", "json"},
+			want: ListOp{AllContexts: true, Count: true, Output: "json"}},
+		{name: "list as json with output flag",
+			args: []string{"-o", "json"},
+			want: ListOp{Output: "json"}},
+		{name: "list at most n namespaces in non-interactive mode",
+			args: []string{"--max-results", "100"},
+			want: ListOp{MaxResults: 100}},
+		{name: "list at most an invalid number of namespaces",
+			args: []string{"--max-results", "0"},
+			want: UnsupportedOp{Err: fmt.Errorf("invalid '--max-results' %q, must be a positive number", "0")}},
+		{name: "list at most n namespaces in every context",
+			args: []string{"-A", "--max-results", "1"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--max-results' can't be combined with '--all-contexts' or '--count'")}},
+		{name: "list without decoration",
+			args: []string{"--no-headers"},
+			want: ListOp{NoHeaders: true}},
+		{name: "list without decoration sorted by name",
+			args: []string{"--sort=name", "--no-headers"},
+			want: ListOp{Sort: "name", NoHeaders: true}},
+		{name: "list with status",
+			args: []string{"--json", "-o", "wide"},
+			want: ListOp{Output: "wide"}},
+		{name: "count with status",
+			args: []string{"--count", "-o", "wide"},
+			want: UnsupportedOp{Err: fmt.Errorf("'-o wide' can't be combined with '--count'")}},
+		{name: "list with unsupported output format",
+			args: []string{"-o", "yaml"},
+			want: UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", "yaml")}},
+		{name: "list with missing output format",
+			args: []string{"-A", "-o"},
+			want: UnsupportedOp{Err: fmt.Errorf("'-o' needs an argument")}},
 		{name: "switch by name",
 			args: []string{"foo"},
 			want: SwitchOp{Target: "foo"}},
+		{name: "print env",
+			args: []string{"--print-env", "foo"},
+			want: PrintEnvOp{Namespace: "foo"}},
+		{name: "print env force",
+			args: []string{"--print-env", "foo", "-f"},
+			want: PrintEnvOp{Namespace: "foo", Force: true}},
+		{name: "print env without name",
+			args: []string{"--print-env"},
+			want: UnsupportedOp{Err: fmt.Errorf("'--print-env' needs a namespace name")}},
 		{name: "switch by name force short flag",
 			args: []string{"foo", "-f"},
 			want: SwitchOp{Target: "foo", Force: true}},
@@ -27,11 +27,15 @@ import (
 	"github.com/ahmetb/kubectx/internal/cmdutil"
 	"github.com/ahmetb/kubectx/internal/env"
 	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
 	"github.com/ahmetb/kubectx/internal/printer"
 )
 
 type InteractiveSwitchOp struct {
-	SelfCmd string
+	SelfCmd    string
+	Sort       string // sort order of the namespaces to choose from
+	Preview    bool   // show the resource counts of the highlighted namespace
+	MaxResults int    // choose from at most this many namespaces, 0 for all
 }
 
 // TODO(ahmetb) This method is heavily repetitive vs kubectx/fzf.go.
@@ -47,14 +51,27 @@ func (op InteractiveSwitchOp) Run(_, stderr io.Writer) error {
 	}
 	defer kc.Close()
 
-	cmd := exec.Command("fzf", "--ansi", "--no-preview")
+	listCmd := op.SelfCmd + " " + bookmarksFirstFlag
+	if op.Sort != "" {
+		listCmd += " --sort=" + op.Sort
+	}
+	args := []string{"--ansi", "--no-preview"}
+	if op.Preview {
+		args = []string{"--ansi", "--preview", fmt.Sprintf("%s %s {}", op.SelfCmd, describeFlag)}
+	}
+	if op.MaxResults > 0 {
+		// the listing can't tell fzf if it left out namespaces, so always note the limit
+		listCmd += fmt.Sprintf(" --max-results %d", op.MaxResults)
+		args = append(args, "--header", fmt.Sprintf("(showing at most %d namespaces)", op.MaxResults))
+	}
+	cmd := exec.Command("fzf", args...)
 	var out bytes.Buffer
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = stderr
 	cmd.Stdout = &out
 
 	cmd.Env = append(os.Environ(),
-		fmt.Sprintf("FZF_DEFAULT_COMMAND=%s", op.SelfCmd),
+		fmt.Sprintf("FZF_DEFAULT_COMMAND=%s", listCmd),
 		fmt.Sprintf("%s=1", env.EnvForceColor))
 	if err := cmd.Run(); err != nil {
 		if _, ok := err.(*exec.ExitError); !ok {
@@ -65,7 +82,10 @@ func (op InteractiveSwitchOp) Run(_, stderr io.Writer) error {
 	if choice == "" {
 		return errors.New("you did not choose any of the options")
 	}
-	name, err := switchNamespace(kc, choice, false)
+	if err := confirmSwitch(stderr, kc.GetCurrentContext(), choice, false); err != nil {
+		return errors.Wrap(err, "failed to switch namespace")
+	}
+	name, err := namespace.Switch(kc, choice, false)
 	if err != nil {
 		return errors.Wrap(err, "failed to switch namespace")
 	}
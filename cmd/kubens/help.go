@@ -35,9 +35,43 @@ func printUsage(out io.Writer) error {
 	help := `USAGE:
   %PROG%                    : list the namespaces in the current context
   %PROG% <NAME>             : change the active namespace of current context
-  %PROG% <NAME> --force/-f  : force change the active namespace of current context (even if it doesn't exist)
+  %PROG% <NAME> --force/-f  : force change the active namespace of current context (even if it doesn't exist or matches KUBENS_DANGER_PATTERN)
   %PROG% -                  : switch to the previous namespace in this context
+  %PROG% --copy-from <CTX>  : switch to the namespace context <CTX> uses, if it exists in the current context
+  %PROG% --create <NAME> [--from <NS>] : create namespace <NAME>, copying the labels and annotations of namespace <NS>
+  %PROG% --peek             : show the namespace '%PROG% -' would switch to, without switching
+  %PROG% --context <CTX> <NAME> : change the active namespace of context <CTX> (without switching to it)
+  %PROG% --preview          : choose a namespace interactively, previewing its pod and deployment counts
   %PROG% -c, --current      : show the current namespace
+  %PROG% -c --context <CTX> : show the namespace of context <CTX> (without switching to it)
+  %PROG% -c [--context <CTX>] -o json : show the context and its namespace as JSON
+  %PROG% --server <URL> ... : same as --context, with the context whose cluster has server <URL>
+  %PROG% --exec <NAME> -- <COMMAND...> : run <COMMAND> in namespace <NAME> without changing the active namespace
+  %PROG% --print-env <NAME> : print shell statements to eval for using namespace <NAME> only in this shell
+  %PROG% --require <PATTERN> : fail unless the current namespace matches the glob <PATTERN> (for CI pipelines)
+  %PROG% --pin              : pin the current namespace so switching contexts won't change it
+  %PROG% --unpin            : remove the pin from the current namespace
+  %PROG% --bookmark <NAME>  : bookmark namespace <NAME> of the current context, listed first in interactive mode
+  %PROG% --unbookmark <NAME> : remove the bookmark of namespace <NAME>
+  %PROG% --bookmarks        : list the bookmarked namespaces of the current context
+  %PROG% --sort[=recent]    : list the recently used namespaces of the context first
+  %PROG% --sort=name        : list the namespaces sorted by name
+  %PROG% --max-results <N>  : list (or choose interactively from) at most <N> namespaces
+  %PROG% -A, --all-contexts : list the namespaces in every context (clusters not responding within KUBECTX_HEALTH_TIMEOUT are reported as errors)
+  %PROG% --json, -o json    : list the namespaces in JSON format
+  %PROG% --no-headers       : list only the namespace names, without any decoration (for scripts)
+  %PROG% -o wide            : list the namespaces in JSON format, with their status (phase)
+  %PROG% --count [-A]       : show the number of namespaces (in every context with -A)
+  %PROG% --list-empty [--resources pods|all] [--timeout <DURATION>] [-o json] : list the namespaces without pods (or any common resources)
+  %PROG% --stats [-o json]  : show how many times you switched to each namespace of the current context
+  %PROG% --reset-stats      : clear the namespace usage statistics of the current context
+  %PROG% --refresh-completion-cache [-A] : cache the namespaces (of every context with -A) for tab completion
+  %PROG% --clear-cache [--context <NAME>] : delete the cached namespaces of every context (or only of <NAME>)
+  %PROG% --no-color         : disable colored output (can be combined with other flags)
+  %PROG% --no-interactive   : never use fzf, even in a terminal (can be combined with other flags, flags that need fzf like --preview then fail)
+  %PROG% --out <FILE>       : write the list or current namespace to <FILE> instead of stdout (replaced atomically)
+  %PROG% --backup           : back up the kubeconfig before modifying it (can be combined with other flags)
+  %PROG% --config <FILE>    : read the defaults from <FILE> instead of ~/.config/kubectx/config.yaml
   %PROG% -h,--help          : show this message
   %PROG% -V,--version       : show version`
 
@@ -16,21 +16,146 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"facette.io/natsort"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	_ "k8s.io/client-go/plugin/pkg/client/auth"
-	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeclient"
 	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
 	"github.com/ahmetb/kubectx/internal/printer"
 )
 
-type ListOp struct{}
+const (
+	outputJSON = "json"
+	outputWide = "wide" // JSON, with the status of each namespace
+
+	sortRecent = "recent" // recently used namespaces of the context first
+	sortName   = "name"
+)
+
+type ListOp struct {
+	AllContexts bool   // list namespaces of every context in kubeconfig
+	Count       bool   // print the number of namespaces instead of names
+	Output      string // output format, "" for plain text, "json" or "wide"
+	Sort        string // "" for the order returned by the API, or sortRecent or sortName
+	MaxResults  int    // list at most this many namespaces of the current context, 0 for all
+	NoHeaders   bool   // print only the names, without highlighting the current namespace
+
+	BookmarksFirst bool // list the bookmarked namespaces of the current context first
+}
+
+// contextNamespaces is the JSON representation of the namespaces in a context.
+type contextNamespaces struct {
+	Context    string   `json:"context"`
+	Namespaces []string `json:"namespaces,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// namespaceStatus is the JSON representation of a namespace in the wide
+// output format.
+type namespaceStatus struct {
+	Name  string `json:"name"`
+	Phase string `json:"phase"`
+}
+
+// contextNamespaceStatuses is the wide JSON representation of the
+// namespaces in a context.
+type contextNamespaceStatuses struct {
+	Context    string            `json:"context"`
+	Namespaces []namespaceStatus `json:"namespaces,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// namespaceCount is the JSON representation of the number of namespaces in
+// a context. Count is -1 if the namespaces of the context couldn't be listed.
+type namespaceCount struct {
+	Context string `json:"context"`
+	Count   int    `json:"count"`
+	Error   string `json:"error,omitempty"`
+}
+
+// parseListArgs parses the flags accepted when listing namespaces, and
+// returns false if argv contains anything other than list flags.
+func parseListArgs(argv []string) (Op, bool) {
+	var op ListOp
+	for i := 0; i < len(argv); i++ {
+		switch v := argv[i]; v {
+		case "--sort":
+			op.Sort = sortRecent
+		case "--sort=" + sortRecent, "--sort=" + sortName:
+			op.Sort = strings.TrimPrefix(v, "--sort=")
+		case "-A", "--all-contexts":
+			op.AllContexts = true
+		case "--count":
+			op.Count = true
+		case "--json":
+			op.Output = outputJSON
+		case "--no-headers":
+			op.NoHeaders = true
+		case bookmarksFirstFlag:
+			op.BookmarksFirst = true
+		case "--max-results":
+			if i+1 >= len(argv) {
+				return UnsupportedOp{Err: fmt.Errorf("'%s' needs an argument", v)}, true
+			}
+			i++
+			n, err := strconv.Atoi(argv[i])
+			if err != nil || n < 1 {
+				return UnsupportedOp{Err: fmt.Errorf("invalid '--max-results' %q, must be a positive number", argv[i])}, true
+			}
+			op.MaxResults = n
+		case "-o", "--output":
+			if i+1 >= len(argv) {
+				return UnsupportedOp{Err: fmt.Errorf("'%s' needs an argument", v)}, true
+			}
+			i++
+			if argv[i] != outputJSON && argv[i] != outputWide {
+				return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[i])}, true
+			}
+			op.Output = argv[i]
+		default:
+			if strings.HasPrefix(v, "--sort=") {
+				return UnsupportedOp{Err: fmt.Errorf("unsupported sort order %q", strings.TrimPrefix(v, "--sort="))}, true
+			}
+			return nil, false
+		}
+	}
+	if op.Count && op.Output == outputWide {
+		return UnsupportedOp{Err: fmt.Errorf("'-o %s' can't be combined with '--count'", outputWide)}, true
+	}
+	if op.MaxResults > 0 && (op.AllContexts || op.Count) {
+		return UnsupportedOp{Err: fmt.Errorf("'--max-results' can't be combined with '--all-contexts' or '--count'")}, true
+	}
+	return op, true
+}
+
+// onlyOrdering returns true if op only sorts or limits the listed namespaces,
+// so they can be chosen from interactively.
+func (op ListOp) onlyOrdering() bool {
+	return op == ListOp{Sort: op.Sort, MaxResults: op.MaxResults, BookmarksFirst: op.BookmarksFirst}
+}
+
+// truncate returns the first op.MaxResults namespaces, and whether any were
+// left out.
+func (op ListOp) truncate(ns []string) ([]string, bool) {
+	if op.MaxResults == 0 || len(ns) <= op.MaxResults {
+		return ns, false
+	}
+	return ns[:op.MaxResults], true
+}
 
 func (op ListOp) Run(stdout, stderr io.Writer) error {
 	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
@@ -39,55 +164,311 @@ func (op ListOp) Run(stdout, stderr io.Writer) error {
 		return errors.Wrap(err, "kubeconfig error")
 	}
 
+	if op.AllContexts {
+		if op.Output == outputWide {
+			return op.listAllContextsWide(kc, stdout, stderr)
+		}
+		return op.listAllContexts(kc, stdout, stderr)
+	}
+
 	ctx := kc.GetCurrentContext()
 	if ctx == "" {
 		return errors.New("current-context is not set")
 	}
+	if op.Output == outputWide {
+		st, err := queryNamespaceStatuses(context.Background(), kc, ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not list namespaces (is the cluster accessible?)")
+		}
+		if err := sortNamespaceStatuses(st, op.Sort, ctx); err != nil {
+			return err
+		}
+		if op.MaxResults > 0 && len(st) > op.MaxResults {
+			st = st[:op.MaxResults]
+		}
+		return writeJSON(stdout, contextNamespaceStatuses{Context: ctx, Namespaces: st})
+	}
 	curNs, err := kc.NamespaceOfContext(ctx)
 	if err != nil {
 		return errors.Wrap(err, "cannot read current namespace")
 	}
 
-	ns, err := queryNamespaces(kc)
+	stop := op.startProgress(stderr, fmt.Sprintf("fetching namespaces of context \"%s\"...", ctx))
+	ns, err := queryNamespaces(context.Background(), kc, ctx)
+	stop()
 	if err != nil {
 		return errors.Wrap(err, "could not list namespaces (is the cluster accessible?)")
 	}
+	if err := sortNamespaces(ns, op.Sort, ctx); err != nil {
+		return err
+	}
+	if op.BookmarksFirst {
+		bookmarks, err := namespace.NewBookmarkFile(ctx).Load()
+		if err != nil {
+			return errors.Wrap(err, "failed to read bookmarks")
+		}
+		sortByRecent(ns, bookmarks)
+	}
+	total := len(ns)
+	ns, truncated := op.truncate(ns)
+
+	if op.Count {
+		return op.printCounts(stdout, stderr, []contextNamespaces{{Context: ctx, Namespaces: ns}})
+	}
+	if op.Output == outputJSON {
+		return writeJSON(stdout, contextNamespaces{Context: ctx, Namespaces: ns})
+	}
 
 	for _, c := range ns {
 		s := c
-		if c == curNs {
+		if c == curNs && !op.NoHeaders {
 			s = printer.ActiveItemColor.Sprint(c)
 		}
 		fmt.Fprintf(stdout, "%s\n", s)
 	}
+	if truncated {
+		printer.Warning(stderr, "showing %d of %d namespaces (see --max-results)", len(ns), total)
+	}
+	return nil
+}
+
+// progressDelay is how long a namespace query can take before a spinner is
+// shown, so fast clusters don't cause flicker.
+const progressDelay = 500 * time.Millisecond
+
+// startProgress shows a spinner with msg on stderr if the query is slow.
+// It is not shown when the output is redirected or with --no-headers, which
+// is meant for scripts. The returned function clears it.
+func (op ListOp) startProgress(stderr io.Writer, msg string) (stop func()) {
+	if op.NoHeaders || !cmdutil.CanShowProgress() {
+		return func() {}
+	}
+	return printer.StartSpinner(stderr, msg, progressDelay, 100*time.Millisecond)
+}
+
+// namespaceQuery lists the namespaces of a context, along with their phase.
+type namespaceQuery func(ctx context.Context, kctx string) ([]namespaceStatus, error)
+
+// queryAllContexts runs the query for each context, at most concurrency at a
+// time, and returns the results in the order of ctxs. Contexts that can't be
+// queried within the timeout have the error in their result, so a single
+// unreachable cluster doesn't stall or fail the listing.
+func queryAllContexts(ctxs []string, concurrency int, timeout time.Duration, query namespaceQuery) []contextNamespaceStatuses {
+	out := make([]contextNamespaceStatuses, len(ctxs))
+	cmdutil.ForEachWithTimeout(len(ctxs), concurrency, timeout, func(ctx context.Context, i int) {
+		out[i] = contextNamespaceStatuses{Context: ctxs[i]}
+		st, err := query(ctx, ctxs[i])
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				err = errors.Wrapf(err, "timed out after %s", timeout)
+			}
+			out[i].Error = err.Error()
+			return
+		}
+		out[i].Namespaces = st
+	})
+	return out
+}
+
+// queryAllContextsSorted queries the namespaces of every context in the
+// kubeconfig, concurrently as set for probing the API servers, sorted in the
+// order of the listing.
+func (op ListOp) queryAllContextsSorted(kc *kubeconfig.Kubeconfig, stderr io.Writer) ([]contextNamespaceStatuses, error) {
+	timeout, concurrency, err := cmdutil.HealthDefaults()
+	if err != nil {
+		return nil, err
+	}
+	ctxs := kc.ContextNames()
+	natsort.Sort(ctxs)
+
+	stop := op.startProgress(stderr, fmt.Sprintf("fetching namespaces of %d contexts...", len(ctxs)))
+	out := queryAllContexts(ctxs, concurrency, timeout, func(ctx context.Context, kctx string) ([]namespaceStatus, error) {
+		return queryNamespaceStatuses(ctx, kc, kctx)
+	})
+	stop()
+	for i, v := range out {
+		if v.Error != "" {
+			continue
+		}
+		if err := sortNamespaceStatuses(v.Namespaces, op.Sort, v.Context); err != nil {
+			out[i].Namespaces, out[i].Error = nil, err.Error()
+		}
+	}
+	return out, nil
+}
+
+// listAllContexts queries the namespaces of each context. Contexts that
+// can't be queried are reported individually instead of failing the listing.
+func (op ListOp) listAllContexts(kc *kubeconfig.Kubeconfig, stdout, stderr io.Writer) error {
+	results, err := op.queryAllContextsSorted(kc, stderr)
+	if err != nil {
+		return err
+	}
+	out := make([]contextNamespaces, 0, len(results))
+	for _, r := range results {
+		v := contextNamespaces{Context: r.Context, Error: r.Error}
+		for _, st := range r.Namespaces {
+			v.Namespaces = append(v.Namespaces, st.Name)
+		}
+		out = append(out, v)
+	}
+
+	if op.Count {
+		return op.printCounts(stdout, stderr, out)
+	}
+	if op.Output == outputJSON {
+		return writeJSON(stdout, out)
+	}
+	for _, v := range out {
+		if v.Error != "" {
+			printer.Warning(stderr, "could not list namespaces of context \"%s\": %s", v.Context, v.Error)
+			continue
+		}
+		for _, ns := range v.Namespaces {
+			fmt.Fprintf(stdout, "%s\t%s\n", v.Context, ns)
+		}
+	}
+	return nil
+}
+
+// listAllContextsWide is like listAllContexts, but writes the status of each
+// namespace in JSON format.
+func (op ListOp) listAllContextsWide(kc *kubeconfig.Kubeconfig, stdout, stderr io.Writer) error {
+	out, err := op.queryAllContextsSorted(kc, stderr)
+	if err != nil {
+		return err
+	}
+	return writeJSON(stdout, out)
+}
+
+// printCounts prints the number of namespaces in each context as aligned
+// columns, or in JSON format.
+func (op ListOp) printCounts(stdout, stderr io.Writer, results []contextNamespaces) error {
+	counts := make([]namespaceCount, 0, len(results))
+	for _, v := range results {
+		c := namespaceCount{Context: v.Context, Count: len(v.Namespaces), Error: v.Error}
+		if v.Error != "" {
+			c.Count = -1
+			if op.Output != outputJSON {
+				printer.Warning(stderr, "could not list namespaces of context \"%s\": %s", v.Context, v.Error)
+			}
+		}
+		counts = append(counts, c)
+	}
+
+	if op.Output == outputJSON {
+		return writeJSON(stdout, counts)
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, c := range counts {
+		fmt.Fprintf(w, "%s\t%d\n", c.Context, c.Count)
+	}
+	return errors.Wrap(w.Flush(), "write error")
+}
+
+// sortNamespaces sorts the namespaces of the context in place, in the
+// specified order.
+func sortNamespaces(ns []string, order, ctx string) error {
+	switch order {
+	case sortName:
+		natsort.Sort(ns)
+	case sortRecent:
+		recent, err := namespace.NewHistoryFile(ctx).Load()
+		if err != nil {
+			return errors.Wrap(err, "failed to read namespace history")
+		}
+		sortByRecent(ns, recent)
+	}
+	return nil
+}
+
+// sortByRecent moves the recently used namespaces to the front, most recent
+// first. The other namespaces keep their order. It's also used to move the
+// bookmarked namespaces to the front.
+func sortByRecent(ns, recent []string) {
+	rank := make(map[string]int, len(recent))
+	for i, v := range recent {
+		rank[v] = i
+	}
+	sort.SliceStable(ns, func(i, j int) bool {
+		ri, oki := rank[ns[i]]
+		rj, okj := rank[ns[j]]
+		if oki && okj {
+			return ri < rj
+		}
+		return oki && !okj
+	})
+}
+
+// sortNamespaceStatuses is like sortNamespaces, for namespaces with their
+// status.
+func sortNamespaceStatuses(st []namespaceStatus, order, ctx string) error {
+	names := make([]string, len(st))
+	byName := make(map[string]namespaceStatus, len(st))
+	for i, v := range st {
+		names[i] = v.Name
+		byName[v.Name] = v
+	}
+	if err := sortNamespaces(names, order, ctx); err != nil {
+		return err
+	}
+	for i, n := range names {
+		st[i] = byName[n]
+	}
 	return nil
 }
 
-func queryNamespaces(kc *kubeconfig.Kubeconfig) ([]string, error) {
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return errors.Wrap(enc.Encode(v), "write error")
+}
+
+// queryNamespaces lists the namespaces in the cluster of the specified context.
+func queryNamespaces(ctx context.Context, kc *kubeconfig.Kubeconfig, kctx string) ([]string, error) {
+	st, err := queryNamespaceStatuses(ctx, kc, kctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(st))
+	for i, v := range st {
+		out[i] = v.Name
+	}
+	return out, nil
+}
+
+// queryNamespaceStatuses lists the namespaces in the cluster of the specified
+// context, along with their phase.
+func queryNamespaceStatuses(ctx context.Context, kc *kubeconfig.Kubeconfig, kctx string) ([]namespaceStatus, error) {
 	if os.Getenv("_MOCK_NAMESPACES") != "" {
-		return []string{"ns1", "ns2"}, nil
+		return []namespaceStatus{{"ns1", string(corev1.NamespaceActive)}, {"ns2", string(corev1.NamespaceActive)}}, nil
 	}
 
-	clientset, err := newKubernetesClientSet(kc)
+	clientset, err := kubeclient.NewClientSet(kc, kctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize k8s REST client")
 	}
 
-	var out []string
+	var out []namespaceStatus
 	var next string
 	for {
-		list, err := clientset.CoreV1().Namespaces().List(
-			context.Background(),
-			metav1.ListOptions{
-				Limit:    500,
-				Continue: next,
-			})
+		var list *corev1.NamespaceList
+		err := withRetry(func() error {
+			var err error
+			list, err = clientset.CoreV1().Namespaces().List(
+				ctx,
+				metav1.ListOptions{
+					Limit:    500,
+					Continue: next,
+				})
+			return err
+		})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to list namespaces from k8s API")
 		}
 		next = list.Continue
 		for _, it := range list.Items {
-			out = append(out, it.Name)
+			out = append(out, namespaceStatus{Name: it.Name, Phase: string(it.Status.Phase)})
 		}
 		if next == "" {
 			break
@@ -95,15 +476,3 @@ func queryNamespaces(kc *kubeconfig.Kubeconfig) ([]string, error) {
 	}
 	return out, nil
 }
-
-func newKubernetesClientSet(kc *kubeconfig.Kubeconfig) (*kubernetes.Clientset, error) {
-	b, err := kc.Bytes()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert in-memory kubeconfig to yaml")
-	}
-	cfg, err := clientcmd.RESTConfigFromKubeConfig(b)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to initialize config")
-	}
-	return kubernetes.NewForConfig(cfg)
-}
@@ -0,0 +1,80 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_sortByRecent(t *testing.T) {
+	ns := []string{"default", "kube-system", "a", "b", "c"}
+	sortByRecent(ns, []string{"c", "removed", "a"})
+	want := []string{"c", "a", "default", "kube-system", "b"}
+	if diff := cmp.Diff(want, ns); diff != "" {
+		t.Fatalf("sortByRecent() diff=%s", diff)
+	}
+}
+
+func Test_sortNamespaceStatuses(t *testing.T) {
+	st := []namespaceStatus{
+		{Name: "ns10", Phase: "Active"},
+		{Name: "ns2", Phase: "Terminating"},
+		{Name: "default", Phase: "Active"},
+	}
+	if err := sortNamespaceStatuses(st, sortName, "ctx"); err != nil {
+		t.Fatal(err)
+	}
+	want := []namespaceStatus{
+		{Name: "default", Phase: "Active"},
+		{Name: "ns2", Phase: "Terminating"},
+		{Name: "ns10", Phase: "Active"},
+	}
+	if diff := cmp.Diff(want, st); diff != "" {
+		t.Fatalf("sortNamespaceStatuses() diff=%s", diff)
+	}
+}
+
+func Test_queryAllContexts(t *testing.T) {
+	query := func(ctx context.Context, kctx string) ([]namespaceStatus, error) {
+		switch kctx {
+		case "down":
+			return nil, errors.New("connection refused")
+		case "slow":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []namespaceStatus{{Name: "default", Phase: "Active"}}, nil
+	}
+
+	start := time.Now()
+	got := queryAllContexts([]string{"up", "slow", "down", "other"}, 2, 20*time.Millisecond, query)
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("queries took %s, the slow contexts weren't cut off", d)
+	}
+	want := []contextNamespaceStatuses{
+		{Context: "up", Namespaces: []namespaceStatus{{Name: "default", Phase: "Active"}}},
+		{Context: "slow", Error: "timed out after 20ms: " + context.DeadlineExceeded.Error()},
+		{Context: "down", Error: "connection refused"},
+		{Context: "other", Namespaces: []namespaceStatus{{Name: "default", Phase: "Active"}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("queryAllContexts() diff=%s", diff)
+	}
+}
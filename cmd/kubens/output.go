@@ -0,0 +1,62 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// FileOutputOp runs Op, writing its output to the file at Path instead of
+// stdout. The file is replaced atomically, so readers like prompts never see
+// a partial output.
+type FileOutputOp struct {
+	Op   Op
+	Path string
+}
+
+// withOutFile returns an op writing the output of op to the file at path, if
+// op supports it.
+func withOutFile(op Op, path string) Op {
+	switch v := op.(type) {
+	case InteractiveSwitchOp:
+		// stdout is a terminal, but the list is written to the file
+		if !v.Preview {
+			return FileOutputOp{Op: ListOp{Sort: v.Sort, MaxResults: v.MaxResults}, Path: path}
+		}
+	case ListOp, CurrentOp:
+		return FileOutputOp{Op: op, Path: path}
+	case UnsupportedOp:
+		return op
+	}
+	return UnsupportedOp{Err: fmt.Errorf("'--out' is only supported when listing namespaces or showing the current namespace")}
+}
+
+func (op FileOutputOp) Run(_, stderr io.Writer) error {
+	// the file is read by programs, not shown in a terminal
+	printer.DisableColors()
+
+	var out bytes.Buffer
+	if err := op.Op.Run(&out, stderr); err != nil {
+		return err
+	}
+	return errors.Wrapf(cmdutil.WriteFileAtomic(op.Path, out.Bytes()), "failed to write \"%s\"", op.Path)
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_withOutFile(t *testing.T) {
+	tests := []struct {
+		name string
+		op   Op
+		want Op
+	}{
+		{name: "list",
+			op:   ListOp{},
+			want: FileOutputOp{Op: ListOp{}, Path: "out"}},
+		{name: "current",
+			op:   CurrentOp{},
+			want: FileOutputOp{Op: CurrentOp{}, Path: "out"}},
+		{name: "interactive mode lists instead",
+			op:   InteractiveSwitchOp{SelfCmd: "self"},
+			want: FileOutputOp{Op: ListOp{}, Path: "out"}},
+		{name: "unsupported op",
+			op:   HelpOp{},
+			want: UnsupportedOp{Err: fmt.Errorf("'--out' is only supported when listing namespaces or showing the current namespace")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := withOutFile(tt.op, "out")
+			var opts cmp.Options
+			if _, ok := tt.want.(UnsupportedOp); ok {
+				opts = append(opts, cmp.Comparer(func(x, y UnsupportedOp) bool {
+					return (x.Err == nil && y.Err == nil) || (x.Err.Error() == y.Err.Error())
+				}))
+			}
+			if diff := cmp.Diff(tt.want, got, opts...); diff != "" {
+				t.Errorf("withOutFile() diff=%s", diff)
+			}
+		})
+	}
+}
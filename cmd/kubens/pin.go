@@ -0,0 +1,71 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// PinOp indicates intention to pin the namespace of the current context.
+type PinOp struct{}
+
+// UnpinOp indicates intention to unpin the namespace of the current context.
+type UnpinOp struct{}
+
+func (PinOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		return errors.New("current-context is not set")
+	}
+	ns, err := kc.NamespaceOfContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current namespace")
+	}
+	if err := namespace.NewPinFile().Pin(ctx); err != nil {
+		return errors.Wrap(err, "failed to save pinned namespace")
+	}
+	err = printer.Success(stderr, "Pinned namespace \"%s\" of context \"%s\".", printer.SuccessColor.Sprint(ns), ctx)
+	return errors.Wrap(err, "print error")
+}
+
+func (UnpinOp) Run(_, stderr io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		return errors.New("current-context is not set")
+	}
+	if err := namespace.NewPinFile().Unpin(ctx); err != nil {
+		return errors.Wrap(err, "failed to remove pinned namespace")
+	}
+	err := printer.Success(stderr, "Unpinned namespace of context \"%s\".", ctx)
+	return errors.Wrap(err, "print error")
+}
@@ -0,0 +1,178 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/kubeclient"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+const (
+	// describeFlag is the hidden flag fzf calls kubens with to preview a
+	// namespace.
+	describeFlag = "--describe-namespace"
+
+	previewTimeout  = 2 * time.Second
+	previewCacheTTL = 30 * time.Second
+)
+
+var defaultPreviewCacheDir = filepath.Join(cmdutil.HomeDir(), ".kube", "kubens-preview-cache")
+
+// DescribeOp prints the number of resources in a namespace of the current
+// context, for the interactive mode preview.
+type DescribeOp struct {
+	Namespace string
+}
+
+// resourceCounts is the number of resources in a namespace, as cached.
+type resourceCounts struct {
+	Pods        int       `json:"pods"`
+	Deployments int       `json:"deployments"`
+	Time        time.Time `json:"time"`
+}
+
+// parsePreviewArgs parses the arguments accompanying --preview, which can
+// only be the flags sorting or limiting the namespaces.
+func parsePreviewArgs(argv []string) Op {
+	var l ListOp
+	if len(argv) > 0 {
+		op, ok := parseListArgs(argv)
+		var isList bool
+		l, isList = op.(ListOp)
+		if !ok || !isList || !l.onlyOrdering() {
+			return UnsupportedOp{Err: fmt.Errorf("'--preview' can only be combined with '--sort' and '--max-results'")}
+		}
+	}
+	if !cmdutil.IsInteractiveMode(os.Stdout) {
+		return UnsupportedOp{Err: fmt.Errorf("'--preview' needs interactive mode (fzf installed and a terminal)")}
+	}
+	return InteractiveSwitchOp{SelfCmd: os.Args[0], Sort: l.Sort, MaxResults: l.MaxResults, Preview: true}
+}
+
+// previewCacheFile returns the path of the cached resource counts of the
+// namespace in the context.
+func previewCacheFile(dir, ctx, ns string) string {
+	return filepath.Join(dir, cacheFileName(ctx), ns)
+}
+
+// cacheFileName returns ctx with the characters that can't be used in file
+// names replaced.
+func cacheFileName(ctx string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "__").Replace(ctx)
+}
+
+// readCachedCounts returns the cached counts, or nil if there are none newer
+// than the TTL.
+func readCachedCounts(path string, now time.Time) *resourceCounts {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var v resourceCounts
+	if err := json.Unmarshal(b, &v); err != nil || now.Sub(v.Time) > previewCacheTTL {
+		return nil
+	}
+	return &v
+}
+
+// writeCachedCounts saves the counts, creating missing parent directories.
+func writeCachedCounts(path string, v resourceCounts) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// countResources queries the number of pods and deployments in the namespace.
+func countResources(ctx context.Context, kc *kubeconfig.Kubeconfig, kctx, ns string) (resourceCounts, error) {
+	if os.Getenv("_MOCK_NAMESPACES") != "" {
+		return resourceCounts{Pods: 3, Deployments: 1}, nil
+	}
+
+	clientset, err := kubeclient.NewClientSet(kc, kctx)
+	if err != nil {
+		return resourceCounts{}, errors.Wrap(err, "failed to initialize k8s REST client")
+	}
+	// only the first page is fetched, the rest is counted by the server
+	opts := metav1.ListOptions{Limit: 1}
+	pods, err := clientset.CoreV1().Pods(ns).List(ctx, opts)
+	if err != nil {
+		return resourceCounts{}, errors.Wrap(err, "failed to list pods")
+	}
+	deploys, err := clientset.AppsV1().Deployments(ns).List(ctx, opts)
+	if err != nil {
+		return resourceCounts{}, errors.Wrap(err, "failed to list deployments")
+	}
+	return resourceCounts{Pods: listCount(len(pods.Items), pods.RemainingItemCount),
+		Deployments: listCount(len(deploys.Items), deploys.RemainingItemCount)}, nil
+}
+
+func listCount(n int, remaining *int64) int {
+	if remaining != nil {
+		n += int(*remaining)
+	}
+	return n
+}
+
+// Run prints the resource counts. Failures are printed as the preview
+// instead of failing, so fzf shows why the counts aren't available.
+func (op DescribeOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	kctx := kc.GetCurrentContext()
+	if kctx == "" {
+		return errors.New("current-context is not set")
+	}
+
+	now := time.Now()
+	path := previewCacheFile(defaultPreviewCacheDir, kctx, op.Namespace)
+	counts := readCachedCounts(path, now)
+	if counts == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), previewTimeout)
+		defer cancel()
+		v, err := countResources(ctx, kc, kctx, op.Namespace)
+		if err != nil {
+			_, err = fmt.Fprintf(stdout, "Resource counts unavailable: %v\n", err)
+			return errors.Wrap(err, "write error")
+		}
+		v.Time = now
+		_ = writeCachedCounts(path, v) // caching is best effort
+		counts = &v
+	}
+	_, err := fmt.Fprintf(stdout, "Namespace:   %s\nPods:        %d\nDeployments: %d\n",
+		op.Namespace, counts.Pods, counts.Deployments)
+	return errors.Wrap(err, "write error")
+}
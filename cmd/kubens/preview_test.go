@@ -0,0 +1,53 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_cachedCounts(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "preview-cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := previewCacheFile(dir, "arn:aws:eks:us-east-1:1:cluster/foo", "ns")
+
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	if v := readCachedCounts(path, now); v != nil {
+		t.Fatalf("expected no cached counts; got=%v", v)
+	}
+
+	want := resourceCounts{Pods: 5, Deployments: 2, Time: now}
+	if err := writeCachedCounts(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got := readCachedCounts(path, now.Add(previewCacheTTL))
+	if got == nil {
+		t.Fatal("expected cached counts within TTL")
+	}
+	if diff := cmp.Diff(want, *got); diff != "" {
+		t.Fatalf("readCachedCounts() diff=%s", diff)
+	}
+	if v := readCachedCounts(path, now.Add(previewCacheTTL+time.Second)); v != nil {
+		t.Fatalf("expected expired cached counts to be ignored; got=%v", v)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
+)
+
+// PrintEnvOp indicates intention to print shell statements selecting the
+// namespace for the current shell only, without changing the kubeconfig.
+type PrintEnvOp struct {
+	Namespace string
+	Force     bool // print even if the namespace doesn't exist
+}
+
+// parsePrintEnvArgs parses the arguments following --print-env.
+func parsePrintEnvArgs(argv []string) Op {
+	var op PrintEnvOp
+	var positional []string
+	for _, v := range argv {
+		if v == "-f" || v == "--force" {
+			op.Force = true
+			continue
+		}
+		positional = append(positional, v)
+	}
+	if len(positional) != 1 {
+		return UnsupportedOp{Err: fmt.Errorf("'--print-env' needs a namespace name")}
+	}
+	op.Namespace = positional[0]
+	return op
+}
+
+func (op PrintEnvOp) Run(stdout, _ io.Writer) error {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return errors.Wrap(err, "kubeconfig error")
+	}
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		return errors.New("current-context is not set")
+	}
+
+	if !op.Force {
+		ok, err := namespace.Exists(kc, ctx, op.Namespace)
+		if err != nil {
+			return errors.Wrap(err, "failed to query if namespace exists (is cluster accessible?)")
+		}
+		if !ok {
+			return errors.Errorf("no namespace exists with name \"%s\"", op.Namespace)
+		}
+	}
+	_, err := fmt.Fprintf(stdout, "export %s=%s\n", env.EnvKubensNamespace, cmdutil.ShellQuote(op.Namespace))
+	return errors.Wrap(err, "write error")
+}
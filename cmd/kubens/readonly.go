@@ -0,0 +1,32 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// readOnly determines if the operation can run in read-only mode, as it
+// doesn't modify the kubeconfig or the state files. Operations are assumed
+// to modify them unless listed here. The namespace caches under ~/.kube only
+// hold what can be queried again, so the operations writing or clearing them
+// (completion included) are allowed.
+func readOnly(op Op) bool {
+	switch op := op.(type) {
+	case FileOutputOp:
+		return readOnly(op.Op)
+	case UnsupportedOp, HelpOp, VersionOp, ListOp, CurrentOp, PeekOp, DescribeOp,
+		CompleteOp, RefreshCompletionCacheOp, ClearCacheOp, ListBookmarksOp, ListEmptyOp, StatsOp,
+		RequireOp, PrintEnvOp, ExecOp:
+		return true
+	}
+	return false
+}
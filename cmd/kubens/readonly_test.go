@@ -0,0 +1,36 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func Test_readOnly(t *testing.T) {
+	tests := []struct {
+		op   Op
+		want bool
+	}{
+		{ListOp{AllContexts: true}, true},
+		{FileOutputOp{Op: CurrentOp{}}, true},
+		{SwitchOp{Target: "a"}, false},
+		{PinOp{}, false},
+		{BookmarkOp{Namespace: "a"}, false},
+		{ResetStatsOp{}, false},
+	}
+	for _, tt := range tests {
+		if got := readOnly(tt.op); got != tt.want {
+			t.Errorf("readOnly(%#v)=%v; want=%v", tt.op, got, tt.want)
+		}
+	}
+}
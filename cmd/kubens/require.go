@@ -0,0 +1,47 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+// RequireOp fails unless the namespace of the current context matches the
+// glob pattern, without switching.
+type RequireOp struct {
+	Namespace string // glob pattern of the namespace
+}
+
+func (op RequireOp) Run(_, _ io.Writer) error {
+	kc, ctx, err := currentContext()
+	if err != nil {
+		return err
+	}
+	defer kc.Close()
+
+	ns, err := kc.NamespaceOfContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to read namespace of current context")
+	}
+	if !cmdutil.MatchGlob(op.Namespace, ns) {
+		return errors.Errorf("current namespace \"%s\" of context \"%s\" does not match \"%s\"",
+			ns, ctx, op.Namespace)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/ahmetb/kubectx/internal/env"
+)
+
+const (
+	defaultRetries = 2
+	maxRetries     = 10
+	initialBackoff = 250 * time.Millisecond
+)
+
+// sleep is replaced in tests.
+var sleep = time.Sleep
+
+// retryCount returns how many times a failed API call should be retried,
+// based on the environment configuration.
+func retryCount() (int, error) {
+	v := os.Getenv(env.EnvKubensRetries)
+	if v == "" {
+		return defaultRetries, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf("invalid %s value %q, must be a non-negative integer", env.EnvKubensRetries, v)
+	}
+	if n > maxRetries {
+		n = maxRetries
+	}
+	return n, nil
+}
+
+// isRetryable determines if the error returned from the Kubernetes API is
+// likely to be transient, such as timeouts, refused or reset connections and
+// server-side failures. Other errors, like authentication failures, invalid
+// certificates, unknown hosts and expired contexts, are never retried.
+func isRetryable(err error) bool {
+	if err == nil || apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) {
+		return false
+	}
+	// the time given to the query is up, retrying can only fail the same way
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		code := status.Status().Code
+		return code >= 500 || code == 429
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// withRetry calls fn until it succeeds, fails with an error that's not
+// retryable, or the configured number of retries is exhausted. The wait
+// between attempts grows exponentially.
+func withRetry(fn func() error) error {
+	retries, err := retryCount()
+	if err != nil {
+		return err
+	}
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if attempt >= retries {
+			if retries > 0 {
+				return errors.Wrapf(err, "failed after %d attempts", attempt+1)
+			}
+			return err
+		}
+		sleep(backoff)
+		backoff *= 2
+	}
+}
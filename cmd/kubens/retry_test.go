@@ -0,0 +1,141 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_isRetryable(t *testing.T) {
+	gr := schema.GroupResource{Resource: "namespaces"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"unauthorized", apierrors.NewUnauthorized("no"), false},
+		{"forbidden", apierrors.NewForbidden(gr, "", errors.New("no")), false},
+		{"not found", apierrors.NewNotFound(gr, "foo"), false},
+		{"internal error", apierrors.NewInternalError(errors.New("oops")), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("down"), true},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), true},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"wrapped connection reset", errors.Wrap(&url.Error{Op: "Get", URL: "https://x",
+			Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}}, "list"), true},
+		{"timeout", &url.Error{Op: "Get", URL: "https://x", Err: &net.DNSError{Err: "timeout", IsTimeout: true}}, true},
+		{"expired context", &url.Error{Op: "Get", URL: "https://x", Err: context.DeadlineExceeded}, false},
+		{"unknown host", errors.Wrap(&net.DNSError{Err: "no such host", IsNotFound: true}, "list"), false},
+		{"unknown certificate authority", &url.Error{Op: "Get", URL: "https://x", Err: x509.UnknownAuthorityError{}}, false},
+		{"credential plugin failure", &url.Error{Op: "Get", URL: "https://x", Err: errors.New("exec: executable not found")}, false},
+		{"other error", errors.New("something else"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v)=%v; expected=%v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_withRetry(t *testing.T) {
+	origSleep := sleep
+	defer func() { sleep = origSleep }()
+	var waits []time.Duration
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	transient := apierrors.NewServiceUnavailable("down")
+
+	t.Run("succeeds after transient errors", func(t *testing.T) {
+		waits = nil
+		defer testutil.WithEnvVar("KUBENS_RETRIES", "3")()
+		calls := 0
+		err := withRetry(func() error {
+			calls++
+			if calls < 3 {
+				return transient
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 calls; got=%d", calls)
+		}
+		if len(waits) != 2 || waits[1] != 2*waits[0] {
+			t.Fatalf("expected exponential backoff; got=%v", waits)
+		}
+	})
+
+	t.Run("gives up after retries", func(t *testing.T) {
+		defer testutil.WithEnvVar("KUBENS_RETRIES", "2")()
+		calls := 0
+		err := withRetry(func() error { calls++; return transient })
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 calls; got=%d", calls)
+		}
+	})
+
+	t.Run("does not retry certificate errors", func(t *testing.T) {
+		defer testutil.WithEnvVar("KUBENS_RETRIES", "3")()
+		calls := 0
+		err := withRetry(func() error {
+			calls++
+			return &url.Error{Op: "Get", URL: "https://x", Err: x509.UnknownAuthorityError{}}
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if calls != 1 {
+			t.Fatalf("expected 1 call; got=%d", calls)
+		}
+	})
+
+	t.Run("does not retry auth errors", func(t *testing.T) {
+		defer testutil.WithEnvVar("KUBENS_RETRIES", "5")()
+		calls := 0
+		err := withRetry(func() error { calls++; return apierrors.NewUnauthorized("no") })
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if calls != 1 {
+			t.Fatalf("expected 1 call; got=%d", calls)
+		}
+	})
+
+	t.Run("invalid env value", func(t *testing.T) {
+		defer testutil.WithEnvVar("KUBENS_RETRIES", "many")()
+		if err := withRetry(func() error { return nil }); err == nil {
+			t.Fatal("expected error for invalid retry count")
+		}
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+// StatsOp indicates intention to print how many times each namespace of the
+// current context has been switched to.
+type StatsOp struct {
+	Output string // output format, "" for plain text or "json"
+}
+
+// ResetStatsOp indicates intention to clear the namespace usage statistics
+// of the current context.
+type ResetStatsOp struct{}
+
+// namespaceStats is the usage statistics of a namespace.
+type namespaceStats struct {
+	Namespace string `json:"namespace"`
+	Switches  int    `json:"switches"`
+}
+
+// parseStatsArgs parses the arguments following --stats.
+func parseStatsArgs(argv []string) Op {
+	switch {
+	case len(argv) == 0:
+		return StatsOp{}
+	case len(argv) == 2 && (argv[0] == "-o" || argv[0] == "--output"):
+		if argv[1] != outputJSON {
+			return UnsupportedOp{Err: fmt.Errorf("unsupported output format %q", argv[1])}
+		}
+		return StatsOp{Output: argv[1]}
+	}
+	return UnsupportedOp{Err: fmt.Errorf("unsupported arguments %q", argv)}
+}
+
+// currentHistoryFile returns the namespace history of the current context.
+func currentHistoryFile() (namespace.HistoryFile, error) {
+	kc := new(kubeconfig.Kubeconfig).WithLoader(kubeconfig.DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return namespace.HistoryFile{}, errors.Wrap(err, "kubeconfig error")
+	}
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		return namespace.HistoryFile{}, errors.New("current-context is not set")
+	}
+	return namespace.NewHistoryFile(ctx), nil
+}
+
+func (op StatsOp) Run(stdout, _ io.Writer) error {
+	f, err := currentHistoryFile()
+	if err != nil {
+		return err
+	}
+	entries, err := f.Stats()
+	if err != nil {
+		return errors.Wrap(err, "failed to read namespace history")
+	}
+
+	if op.Output == outputJSON {
+		stats := make([]namespaceStats, len(entries))
+		for i, e := range entries {
+			stats[i] = namespaceStats{Namespace: e.Namespace, Switches: e.Switches}
+		}
+		return writeJSON(stdout, stats)
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%d\n", e.Namespace, e.Switches)
+	}
+	return errors.Wrap(w.Flush(), "write error")
+}
+
+func (_ ResetStatsOp) Run(_, stderr io.Writer) error {
+	f, err := currentHistoryFile()
+	if err != nil {
+		return err
+	}
+	if err := f.ResetStats(); err != nil {
+		return errors.Wrap(err, "failed to save namespace history")
+	}
+	err = printer.Success(stderr, "Cleared the namespace usage statistics of this context.")
+	return errors.Wrap(err, "print error")
+}
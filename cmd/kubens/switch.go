@@ -15,21 +15,22 @@
 package main
 
 import (
-	"context"
+	"fmt"
 	"io"
-	"os"
 
 	"github.com/pkg/errors"
-	errors2 "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/ahmetb/kubectx/internal/cmdutil"
 	"github.com/ahmetb/kubectx/internal/kubeconfig"
+	"github.com/ahmetb/kubectx/internal/namespace"
 	"github.com/ahmetb/kubectx/internal/printer"
 )
 
 type SwitchOp struct {
-	Target string // '-' for back and forth, or NAME
-	Force  bool   // force switch even if the namespace doesn't exist
+	Target  string // '-' for back and forth, or NAME
+	Force   bool   // force switch even if the namespace doesn't exist, without confirming
+	Context string // context to change the namespace of, or "" for current-context
+	Server  string // pick the context by its cluster's server address instead, if set
 }
 
 func (s SwitchOp) Run(_, stderr io.Writer) error {
@@ -39,76 +40,72 @@ func (s SwitchOp) Run(_, stderr io.Writer) error {
 		return errors.Wrap(err, "kubeconfig error")
 	}
 
-	toNS, err := switchNamespace(kc, s.Target, s.Force)
-	if err != nil {
-		return err
-	}
-	err = printer.Success(stderr, "Active namespace is \"%s\"", printer.SuccessColor.Sprint(toNS))
-	return err
-}
-
-func switchNamespace(kc *kubeconfig.Kubeconfig, ns string, force bool) (string, error) {
-	ctx := kc.GetCurrentContext()
-	if ctx == "" {
-		return "", errors.New("current-context is not set")
-	}
-	curNS, err := kc.NamespaceOfContext(ctx)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to get current namespace")
-	}
-
-	f := NewNSFile(ctx)
-	prev, err := f.Load()
-	if err != nil {
-		return "", errors.Wrap(err, "failed to load previous namespace from file")
-	}
-
-	if ns == "-" {
-		if prev == "" {
-			return "", errors.Errorf("No previous namespace found for current context (%s)", ctx)
+	if s.Server != "" {
+		ctx, err := contextByServer(kc, s.Server)
+		if err != nil {
+			return err
 		}
-		ns = prev
+		s.Context = ctx
 	}
-
-	if !force {
-		ok, err := namespaceExists(kc, ns)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to query if namespace exists (is cluster accessible?)")
+	if s.Context != "" {
+		if !kc.ContextExists(s.Context) {
+			return errors.Errorf("no context exists with the name: \"%s\"", s.Context)
 		}
-		if !ok {
-			return "", errors.Errorf("no namespace exists with name \"%s\"", ns)
+		if err := confirmSwitch(stderr, s.Context, s.Target, s.Force); err != nil {
+			return err
 		}
+		toNS, err := namespace.SwitchContext(kc, s.Context, s.Target, s.Force)
+		if err != nil {
+			return withSuggestions(kc, s.Context, err)
+		}
+		return printer.Success(stderr, "Active namespace of context \"%s\" is \"%s\"",
+			s.Context, printer.SuccessColor.Sprint(toNS))
 	}
 
-	if err := kc.SetNamespace(ctx, ns); err != nil {
-		return "", errors.Wrapf(err, "failed to change to namespace \"%s\"", ns)
-	}
-	if err := kc.Save(); err != nil {
-		return "", errors.Wrap(err, "failed to save kubeconfig file")
+	if err := confirmSwitch(stderr, kc.GetCurrentContext(), s.Target, s.Force); err != nil {
+		return err
 	}
-	if curNS != ns {
-		if err := f.Save(curNS); err != nil {
-			return "", errors.Wrap(err, "failed to save the previous namespace to file")
-		}
+	toNS, err := namespace.Switch(kc, s.Target, s.Force)
+	if err != nil {
+		return withSuggestions(kc, kc.GetCurrentContext(), err)
 	}
-	return ns, nil
+	err = printer.Success(stderr, "Active namespace is \"%s\"", printer.SuccessColor.Sprint(toNS))
+	return err
 }
 
-func namespaceExists(kc *kubeconfig.Kubeconfig, ns string) (bool, error) {
-	// for tests
-	if os.Getenv("_MOCK_NAMESPACES") != "" {
-		return ns == "ns1" || ns == "ns2", nil
+// withSuggestions adds the namespaces closest to the missing one to a
+// namespace.NotFoundError. Other errors, or failures to list the namespaces,
+// leave err as is.
+func withSuggestions(kc *kubeconfig.Kubeconfig, ctx string, err error) error {
+	nf, ok := errors.Cause(err).(namespace.NotFoundError)
+	if !ok {
+		return err
+	}
+	names, qErr := cachedQueryNamespaces(kc, ctx)
+	if qErr != nil {
+		return err
 	}
+	return errors.New(err.Error() + cmdutil.DidYouMean(cmdutil.Suggestions(nf.Namespace, names)))
+}
 
-	clientset, err := newKubernetesClientSet(kc)
+// PeekOp indicates intention to print the namespace "kubens -" would switch
+// to, without switching.
+type PeekOp struct{}
+
+func (_ PeekOp) Run(stdout, _ io.Writer) error {
+	kc, ctx, err := currentContext()
 	if err != nil {
-		return false, errors.Wrap(err, "failed to initialize k8s REST client")
+		return err
 	}
+	kc.Close()
 
-	namespace, err := clientset.CoreV1().Namespaces().Get(context.Background(), ns, metav1.GetOptions{})
-	if errors2.IsNotFound(err) {
-		return false, nil
+	prev, err := namespace.NewNSFile(ctx).Load()
+	if err != nil {
+		return errors.Wrap(err, "failed to load previous namespace from file")
+	}
+	if prev == "" {
+		return errors.Errorf("no previous namespace found for current context (%s)", ctx)
 	}
-	return namespace != nil, errors.Wrapf(err, "failed to query "+
-		"namespace %q from k8s API", ns)
+	_, err = fmt.Fprintln(stdout, prev)
+	return errors.Wrap(err, "write error")
 }
@@ -0,0 +1,234 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahmetb/kubectx/internal/env"
+)
+
+// Config holds the defaults read from the config file. Unset fields keep the
+// built-in defaults. Each field has an environment variable, which takes
+// precedence over the config file when it's set.
+type Config struct {
+	Color              *bool  `yaml:"color"`              // false for NO_COLOR
+	Fuzzy              *bool  `yaml:"fuzzy"`              // KUBECTX_FUZZY
+	IgnoreCase         *bool  `yaml:"ignoreCase"`         // KUBECTX_IGNORE_CASE
+	FZF                *bool  `yaml:"fzf"`                // false for KUBECTX_IGNORE_FZF
+	AutoSingle         *bool  `yaml:"autoSingle"`         // KUBECTX_AUTO_SINGLE
+	WarnCrossProject   *bool  `yaml:"warnCrossProject"`   // KUBECTX_WARN_CROSS_PROJECT
+	ReadOnly           *bool  `yaml:"readOnly"`           // KUBECTX_READONLY
+	InteractiveOrder   string `yaml:"interactiveOrder"`   // KUBECTX_INTERACTIVE_ORDER
+	InteractiveCurrent *bool  `yaml:"interactiveCurrent"` // KUBECTX_INTERACTIVE_CURRENT
+	SessionNamespaces  *bool  `yaml:"sessionNamespaces"`  // KUBECTX_SESSION_NAMESPACES
+	DangerPattern      string `yaml:"dangerPattern"`      // KUBECTX_DANGER_PATTERN
+	HealthTimeout      string `yaml:"healthTimeout"`      // KUBECTX_HEALTH_TIMEOUT
+	HealthConcurrency  int    `yaml:"healthConcurrency"`  // KUBECTX_HEALTH_CONCURRENCY
+	BackupDir          string `yaml:"backupDir"`          // KUBECTX_BACKUP_DIR
+	BackupKeep         int    `yaml:"backupKeep"`         // KUBECTX_BACKUP_KEEP
+	KubensRetries      *int   `yaml:"kubensRetries"`      // KUBENS_RETRIES
+
+	KubensDangerPattern string `yaml:"kubensDangerPattern"` // KUBENS_DANGER_PATTERN
+}
+
+// ColorDisabled determines if the config file turns off colored output.
+func (c Config) ColorDisabled() bool {
+	return c.Color != nil && !*c.Color
+}
+
+// defaultConfigFile returns the config file path to use when it isn't given
+// explicitly: $KUBECTX_CONFIG, or config.yaml in the kubectx directory of
+// $XDG_CONFIG_HOME (~/.config by default).
+func defaultConfigFile() string {
+	if v := os.Getenv(env.EnvConfig); v != "" {
+		return v
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" || !filepath.IsAbs(dir) {
+		home := HomeDir()
+		if home == "" {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "kubectx", "config.yaml")
+}
+
+// ConfigExtension is the name of the extension in the kubeconfig preferences
+// that can hold the same settings as the config file.
+const ConfigExtension = "kubectx"
+
+// LoadConfig reads the config file at path (or the default config file if
+// path is empty), and sets the environment variables of its fields that
+// aren't already set in the environment. Fields not set in the config file
+// are taken from fallback, the settings in the kubeconfig preferences.
+// A missing default config file is not an error.
+func LoadConfig(path string, fallback Config) (Config, error) {
+	cfg, err := readConfig(path)
+	if err != nil {
+		return cfg, err
+	}
+	cfg = cfg.withFallback(fallback)
+	for k, v := range cfg.environ() {
+		if _, ok := os.LookupEnv(k); !ok {
+			if err := os.Setenv(k, v); err != nil {
+				return cfg, errors.Wrapf(err, "failed to set %s", k)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+func readConfig(path string) (Config, error) {
+	var cfg Config
+	explicit := path != ""
+	if !explicit {
+		if path = defaultConfigFile(); path == "" {
+			return cfg, nil
+		}
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return cfg, nil
+		}
+		return cfg, errors.Wrap(err, "failed to read config file")
+	}
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return cfg, errors.Wrapf(err, "failed to parse config file %s", path)
+	}
+	return cfg, nil
+}
+
+// withFallback returns the config with its unset fields set from fallback.
+func (c Config) withFallback(fallback Config) Config {
+	if c.Color == nil {
+		c.Color = fallback.Color
+	}
+	if c.Fuzzy == nil {
+		c.Fuzzy = fallback.Fuzzy
+	}
+	if c.IgnoreCase == nil {
+		c.IgnoreCase = fallback.IgnoreCase
+	}
+	if c.FZF == nil {
+		c.FZF = fallback.FZF
+	}
+	if c.AutoSingle == nil {
+		c.AutoSingle = fallback.AutoSingle
+	}
+	if c.WarnCrossProject == nil {
+		c.WarnCrossProject = fallback.WarnCrossProject
+	}
+	if c.ReadOnly == nil {
+		c.ReadOnly = fallback.ReadOnly
+	}
+	if c.InteractiveOrder == "" {
+		c.InteractiveOrder = fallback.InteractiveOrder
+	}
+	if c.InteractiveCurrent == nil {
+		c.InteractiveCurrent = fallback.InteractiveCurrent
+	}
+	if c.SessionNamespaces == nil {
+		c.SessionNamespaces = fallback.SessionNamespaces
+	}
+	if c.DangerPattern == "" {
+		c.DangerPattern = fallback.DangerPattern
+	}
+	if c.HealthTimeout == "" {
+		c.HealthTimeout = fallback.HealthTimeout
+	}
+	if c.HealthConcurrency == 0 {
+		c.HealthConcurrency = fallback.HealthConcurrency
+	}
+	if c.BackupDir == "" {
+		c.BackupDir = fallback.BackupDir
+	}
+	if c.BackupKeep == 0 {
+		c.BackupKeep = fallback.BackupKeep
+	}
+	if c.KubensRetries == nil {
+		c.KubensRetries = fallback.KubensRetries
+	}
+	if c.KubensDangerPattern == "" {
+		c.KubensDangerPattern = fallback.KubensDangerPattern
+	}
+	return c
+}
+
+// environ returns the environment variables equivalent to the fields set in
+// the config.
+func (c Config) environ() map[string]string {
+	out := make(map[string]string)
+	if c.ColorDisabled() {
+		out[env.EnvNoColor] = "1"
+	}
+	if c.Fuzzy != nil && *c.Fuzzy {
+		out[env.EnvFuzzy] = "1"
+	}
+	if c.IgnoreCase != nil && *c.IgnoreCase {
+		out[env.EnvIgnoreCase] = "1"
+	}
+	if c.FZF != nil && !*c.FZF {
+		out[env.EnvFZFIgnore] = "1"
+	}
+	if c.AutoSingle != nil && *c.AutoSingle {
+		out[env.EnvAutoSingle] = "1"
+	}
+	if c.WarnCrossProject != nil && *c.WarnCrossProject {
+		out[env.EnvWarnCrossProject] = "1"
+	}
+	if c.ReadOnly != nil && *c.ReadOnly {
+		out[env.EnvReadOnly] = "1"
+	}
+	if c.InteractiveOrder != "" {
+		out[env.EnvInteractiveOrder] = c.InteractiveOrder
+	}
+	if c.InteractiveCurrent != nil && *c.InteractiveCurrent {
+		out[env.EnvInteractiveCurrent] = "1"
+	}
+	if c.SessionNamespaces != nil && *c.SessionNamespaces {
+		out[env.EnvSessionNamespaces] = "1"
+	}
+	if c.DangerPattern != "" {
+		out[env.EnvDangerPattern] = c.DangerPattern
+	}
+	if c.HealthTimeout != "" {
+		out[env.EnvHealthTimeout] = c.HealthTimeout
+	}
+	if c.HealthConcurrency != 0 {
+		out[env.EnvHealthConcurrency] = strconv.Itoa(c.HealthConcurrency)
+	}
+	if c.BackupDir != "" {
+		out[env.EnvBackupDir] = c.BackupDir
+	}
+	if c.BackupKeep != 0 {
+		out[env.EnvBackupKeep] = strconv.Itoa(c.BackupKeep)
+	}
+	if c.KubensRetries != nil {
+		out[env.EnvKubensRetries] = strconv.Itoa(*c.KubensRetries)
+	}
+	if c.KubensDangerPattern != "" {
+		out[env.EnvKubensDangerPattern] = c.KubensDangerPattern
+	}
+	return out
+}
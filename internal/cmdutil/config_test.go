@@ -0,0 +1,98 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestLoadConfig(t *testing.T) {
+	// clear the variables set by the config file, restoring them afterwards
+	for _, k := range []string{env.EnvNoColor, env.EnvFuzzy, env.EnvFZFIgnore, env.EnvKubensRetries} {
+		defer testutil.WithEnvVar(k, "")()
+		os.Unsetenv(k)
+	}
+	defer testutil.WithEnvVar(env.EnvFuzzy, "0")()
+
+	path, cleanup := testutil.TempFile(t, "color: false\nfuzzy: true\nfzf: false\nkubensRetries: 0\n")
+	defer cleanup()
+
+	cfg, err := LoadConfig(path, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.ColorDisabled() {
+		t.Fatal("expected colors to be disabled")
+	}
+	for k, want := range map[string]string{
+		env.EnvNoColor:       "1",
+		env.EnvFuzzy:         "0", // the environment takes precedence
+		env.EnvFZFIgnore:     "1",
+		env.EnvKubensRetries: "0",
+	} {
+		if got := os.Getenv(k); got != want {
+			t.Errorf("%s=%q; expected=%q", k, got, want)
+		}
+	}
+}
+
+func TestLoadConfig_missingFile(t *testing.T) {
+	td, err := ioutil.TempDir(os.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	defer testutil.WithEnvVar(env.EnvConfig, "")()
+	defer testutil.WithEnvVar("XDG_CONFIG_HOME", td)()
+
+	if _, err := LoadConfig("", Config{}); err != nil {
+		t.Fatalf("missing default config file should be ignored, got err=%v", err)
+	}
+	if _, err := LoadConfig(filepath.Join(td, "config.yaml"), Config{}); err == nil {
+		t.Fatal("expected error for missing config file given explicitly")
+	}
+}
+
+func TestLoadConfig_invalid(t *testing.T) {
+	path, cleanup := testutil.TempFile(t, "fuzzy: [")
+	defer cleanup()
+	if _, err := LoadConfig(path, Config{}); err == nil {
+		t.Fatal("expected error for invalid config file")
+	}
+}
+
+func TestConfig_withFallback(t *testing.T) {
+	yes, no := true, false
+	cfg := Config{Fuzzy: &yes, InteractiveOrder: "recent"}.withFallback(Config{
+		Color: &no, Fuzzy: &no, InteractiveOrder: "order", BackupKeep: 3})
+	if !cfg.ColorDisabled() {
+		t.Error("expected color from fallback")
+	}
+	if cfg.Fuzzy == nil || !*cfg.Fuzzy {
+		t.Error("expected fuzzy from the config file")
+	}
+	if cfg.InteractiveOrder != "recent" {
+		t.Errorf("interactiveOrder=%q; expected from the config file", cfg.InteractiveOrder)
+	}
+	if cfg.BackupKeep != 3 {
+		t.Errorf("backupKeep=%d; expected from fallback", cfg.BackupKeep)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/printer"
+)
+
+const (
+	// defaultDangerPattern matches the names of the contexts to confirm
+	// switching to, unless $KUBECTX_DANGER_PATTERN is set.
+	defaultDangerPattern = `prod|production`
+
+	// defaultNamespaceDangerPattern matches the names of the namespaces to
+	// confirm switching to, unless $KUBENS_DANGER_PATTERN is set.
+	defaultNamespaceDangerPattern = `^kube-system$|-prod$`
+)
+
+// DangerPattern returns the regular expression matching the names of the
+// contexts to confirm switching to, or nil if it's disabled by setting
+// $KUBECTX_DANGER_PATTERN to empty.
+func DangerPattern() (*regexp.Regexp, error) {
+	return dangerPattern(env.EnvDangerPattern, defaultDangerPattern)
+}
+
+// NamespaceDangerPattern is like DangerPattern, for the namespaces set with
+// $KUBENS_DANGER_PATTERN.
+func NamespaceDangerPattern() (*regexp.Regexp, error) {
+	return dangerPattern(env.EnvKubensDangerPattern, defaultNamespaceDangerPattern)
+}
+
+func dangerPattern(key, def string) (*regexp.Regexp, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		v = def
+	}
+	if v == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(v)
+	return re, errors.Wrapf(err, "invalid %s", key)
+}
+
+// ConfirmDangerous prints a banner warning that the kind of entry (context
+// or namespace) being switched to is dangerous, and asks to press Enter to
+// continue. It returns false if anything else is entered or the input ends.
+func ConfirmDangerous(in io.Reader, out io.Writer, kind, name string) (bool, error) {
+	msg := fmt.Sprintf("  You are switching to %s \"%s\".  ", kind, name)
+	bar := strings.Repeat("!", len(msg))
+	if _, err := fmt.Fprintf(out, "%s\n%s\n%s\nPress Enter to continue, or type anything else to cancel: ",
+		printer.ErrorColor.Sprint(bar), printer.ErrorColor.Sprint(msg), printer.ErrorColor.Sprint(bar)); err != nil {
+		return false, errors.Wrap(err, "write error")
+	}
+	s := bufio.NewScanner(in)
+	if !s.Scan() {
+		fmt.Fprintln(out)
+		return false, errors.Wrap(s.Err(), "failed to read answer")
+	}
+	return strings.TrimSpace(s.Text()) == "", nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestDangerPattern(t *testing.T) {
+	defer testutil.WithEnvVar(env.EnvDangerPattern, "")()
+	os.Unsetenv(env.EnvDangerPattern)
+	re, err := DangerPattern()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString("gke_production") || re.MatchString("staging") {
+		t.Fatalf("unexpected default pattern %q", re)
+	}
+
+	os.Setenv(env.EnvDangerPattern, "")
+	if re, err := DangerPattern(); err != nil || re != nil {
+		t.Fatalf("expected no pattern when empty; got=%v, err=%v", re, err)
+	}
+
+	os.Setenv(env.EnvDangerPattern, "(")
+	if _, err := DangerPattern(); err == nil {
+		t.Fatal("expected error for an invalid pattern")
+	}
+}
+
+func TestNamespaceDangerPattern(t *testing.T) {
+	defer testutil.WithEnvVar(env.EnvKubensDangerPattern, "")()
+	os.Unsetenv(env.EnvKubensDangerPattern)
+	re, err := NamespaceDangerPattern()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ns, want := range map[string]bool{"kube-system": true, "shop-prod": true, "kube-public": false, "production": false} {
+		if got := re.MatchString(ns); got != want {
+			t.Errorf("default pattern matches %q=%v; want=%v", ns, got, want)
+		}
+	}
+}
+
+func TestConfirmDangerous(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{name: "enter confirms", in: "\n", want: true},
+		{name: "anything else cancels", in: "n\n", want: false},
+		{name: "end of input cancels", in: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := ConfirmDangerous(strings.NewReader(tt.in), &out, "context", "prod")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got=%v, want=%v", got, tt.want)
+			}
+			if !strings.Contains(out.String(), `switching to context "prod"`) {
+				t.Fatalf("expected banner naming the context; got=%q", out.String())
+			}
+		})
+	}
+}
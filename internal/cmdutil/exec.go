@@ -0,0 +1,85 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// ExitCodeError indicates a command run by kubectx or kubens exited with a
+// non-zero code, which they should exit with as well.
+type ExitCodeError struct{ Code int }
+
+func (e ExitCodeError) Error() string { return fmt.Sprintf("command exited with code %d", e.Code) }
+
+// RunCommand runs the command with the environment variables added to the
+// environment of this process, forwarding the interrupt and termination
+// signals to it. If the command exits with a non-zero code, the error is an
+// ExitCodeError.
+func RunCommand(stdout, stderr io.Writer, argv []string, environ ...string) error {
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), environ...)
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "failed to run \"%s\"", argv[0])
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	go func() {
+		for s := range sigs {
+			cmd.Process.Signal(s) // best effort, the process may have exited
+		}
+	}()
+
+	if err := cmd.Wait(); err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return ExitCodeError{Code: ee.ExitCode()}
+		}
+		return errors.Wrapf(err, "failed to run \"%s\"", argv[0])
+	}
+	return nil
+}
+
+// RunWithKubeconfig runs the command like RunCommand, with KUBECONFIG set to
+// a temporary file with the kubeconfig contents, removed after it exits. The
+// pattern names the temporary file, as in ioutil.TempFile.
+func RunWithKubeconfig(stdout, stderr io.Writer, kubeconfig []byte, pattern string, argv []string) error {
+	// TempFile creates the file only readable by the owner, as it has the user credentials
+	f, err := ioutil.TempFile("", pattern)
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary kubeconfig")
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(kubeconfig); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to write temporary kubeconfig")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to write temporary kubeconfig")
+	}
+	return RunCommand(stdout, stderr, argv, "KUBECONFIG="+f.Name())
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	var stdout, stderr bytes.Buffer
+	err := RunCommand(&stdout, &stderr, []string{"sh", "-c", `echo "$FOO"; exit 3`}, "FOO=bar")
+	if ee, ok := err.(ExitCodeError); !ok || ee.Code != 3 {
+		t.Fatalf("expected ExitCodeError with code 3; got=%v", err)
+	}
+	if expected := "bar\n"; stdout.String() != expected {
+		t.Fatalf("stdout=%q; expected=%q", stdout.String(), expected)
+	}
+
+	if err := RunCommand(&stdout, &stderr, []string{"true"}); err != nil {
+		t.Fatalf("expected no error; got=%v", err)
+	}
+	if err := RunCommand(&stdout, &stderr, []string{"kubectx-no-such-command"}); err == nil {
+		t.Fatal("expected error for missing command")
+	} else if _, ok := err.(ExitCodeError); ok {
+		t.Fatalf("missing command should not be an ExitCodeError")
+	}
+}
+
+func TestRunWithKubeconfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	var stdout, stderr bytes.Buffer
+	err := RunWithKubeconfig(&stdout, &stderr, []byte("current-context: a\n"), "kubectx-test-*.yaml",
+		[]string{"sh", "-c", `echo "$KUBECONFIG"; cat "$KUBECONFIG"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitN(stdout.String(), "\n", 2)
+	if expected := "current-context: a\n"; lines[1] != expected {
+		t.Fatalf("kubeconfig=%q; expected=%q", lines[1], expected)
+	}
+	if _, err := os.Stat(lines[0]); !os.IsNotExist(err) {
+		t.Fatalf("temporary kubeconfig %q not removed: %v", lines[0], err)
+	}
+}
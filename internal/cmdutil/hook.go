@@ -0,0 +1,50 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"io"
+	"os"
+	"runtime"
+)
+
+// RunHook runs the command in the environment variable, if it's set, with
+// args appended to it. The command is run by the shell ("sh -c", or "cmd /C"
+// on Windows), and its output goes to stderr, so it doesn't mix with the
+// output of kubectx and kubens.
+func RunHook(stderr io.Writer, key string, args ...string) error {
+	hook := os.Getenv(key)
+	if hook == "" {
+		return nil
+	}
+	return RunCommand(stderr, stderr, hookCommand(runtime.GOOS, hook, args))
+}
+
+// ShellCommand returns the command line running the script with args through
+// the shell of the OS, the same way as hooks are run.
+func ShellCommand(script string, args ...string) []string {
+	return hookCommand(runtime.GOOS, script, args)
+}
+
+// hookCommand returns the command line running the hook with the arguments
+// through the shell of the OS.
+func hookCommand(goos, hook string, args []string) []string {
+	if goos == "windows" {
+		return append([]string{"cmd", "/C", hook}, args...)
+	}
+	// the arguments are passed as positional parameters, so they don't need
+	// to be quoted
+	return append([]string{"sh", "-c", hook + ` "$@"`, "sh"}, args...)
+}
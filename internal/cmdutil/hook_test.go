@@ -0,0 +1,60 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_hookCommand(t *testing.T) {
+	got := hookCommand("linux", "notify --event rename", []string{"a b", "c"})
+	want := []string{"sh", "-c", `notify --event rename "$@"`, "sh", "a b", "c"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("diff=%s", diff)
+	}
+	got = hookCommand("windows", "notify.bat", []string{"a"})
+	if diff := cmp.Diff([]string{"cmd", "/C", "notify.bat", "a"}, got); diff != "" {
+		t.Fatalf("windows diff=%s", diff)
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	var stderr bytes.Buffer
+	if err := RunHook(&stderr, "_TEST_HOOK", "a"); err != nil {
+		t.Fatalf("unset hook err=%v", err)
+	}
+
+	defer testutil.WithEnvVar("_TEST_HOOK", "echo renamed")()
+	if err := RunHook(&stderr, "_TEST_HOOK", "old name", "new"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stderr.String(), "renamed old name new\n"; got != want {
+		t.Fatalf("output=%q; want=%q", got, want)
+	}
+
+	defer testutil.WithEnvVar("_TEST_HOOK", "exit 3")()
+	if err := RunHook(&stderr, "_TEST_HOOK"); err == nil {
+		t.Fatal("expected error for failing hook")
+	}
+}
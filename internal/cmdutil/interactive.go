@@ -17,8 +17,10 @@ package cmdutil
 import (
 	"os"
 	"os/exec"
+	"strconv"
 
 	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
 
 	"github.com/ahmetb/kubectx/internal/env"
 )
@@ -37,8 +39,49 @@ func fzfInstalled() bool {
 	return false
 }
 
-// IsInteractiveMode determines if we can do choosing with fzf.
+// CanPrompt determines if the user can be asked questions, i.e. both stdin
+// and stderr are terminals.
+func CanPrompt() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stderr)
+}
+
+// CanShowProgress determines if progress can be shown on stderr without
+// getting mixed into redirected or piped output, i.e. both stdout and stderr
+// are terminals.
+func CanShowProgress() bool {
+	return isTerminal(os.Stdout) && isTerminal(os.Stderr)
+}
+
+// TerminalWidth returns the number of columns of the terminal f is, or 0 if
+// it isn't a terminal. $COLUMNS takes precedence, if set.
+func TerminalWidth(f *os.File) int {
+	if v, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && v > 0 {
+		return v
+	}
+	if !isTerminal(f) {
+		return 0
+	}
+	w, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return w
+}
+
+// interactiveDisabled is set by DisableInteractiveMode.
+var interactiveDisabled bool
+
+// DisableInteractiveMode turns off the interactive mode for this run,
+// regardless of the terminal and the environment.
+func DisableInteractiveMode() { interactiveDisabled = true }
+
+// IsInteractiveMode determines if we can do choosing with fzf. It's off in
+// read-only mode, where there's nothing to choose for, and when disabled with
+// DisableInteractiveMode.
 func IsInteractiveMode(stdout *os.File) bool {
+	if interactiveDisabled {
+		return false
+	}
 	v := os.Getenv(env.EnvFZFIgnore)
-	return v == "" && isTerminal(stdout) && fzfInstalled()
+	return v == "" && !IsReadOnly() && isTerminal(stdout) && fzfInstalled()
 }
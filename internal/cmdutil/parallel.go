@@ -0,0 +1,75 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/env"
+)
+
+const (
+	defaultHealthTimeout     = 5 * time.Second
+	defaultHealthConcurrency = 8
+)
+
+// HealthDefaults returns the timeout of querying each API server and the
+// number of API servers queried at the same time, as set in the environment
+// or the built-in defaults.
+func HealthDefaults() (time.Duration, int, error) {
+	timeout, concurrency := defaultHealthTimeout, defaultHealthConcurrency
+	if v := os.Getenv(env.EnvHealthTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return 0, 0, errors.Errorf("invalid %s %q", env.EnvHealthTimeout, v)
+		}
+		timeout = d
+	}
+	if v := os.Getenv(env.EnvHealthConcurrency); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, 0, errors.Errorf("invalid %s %q", env.EnvHealthConcurrency, v)
+		}
+		concurrency = n
+	}
+	return timeout, concurrency, nil
+}
+
+// ForEachWithTimeout calls fn for each index below n, at most concurrency at
+// a time, with a context expiring after the timeout, and waits for all of
+// them to return.
+func ForEachWithTimeout(n, concurrency int, timeout time.Duration, fn func(ctx context.Context, i int)) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			fn(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+}
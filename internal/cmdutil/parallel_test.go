@@ -0,0 +1,40 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestHealthDefaults(t *testing.T) {
+	defer testutil.WithEnvVar(env.EnvHealthTimeout, "2s")()
+	defer testutil.WithEnvVar(env.EnvHealthConcurrency, "3")()
+	timeout, concurrency, err := HealthDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout != 2*time.Second || concurrency != 3 {
+		t.Fatalf("HealthDefaults()=%v,%d want 2s,3", timeout, concurrency)
+	}
+
+	defer testutil.WithEnvVar(env.EnvHealthConcurrency, "many")()
+	if _, _, err := HealthDefaults(); err == nil {
+		t.Fatal("expected error for invalid concurrency")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_levenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"prod", "prdo", 2},
+		{"ümlaut", "umlaut", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q)=%d; want=%d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	names := []string{"prod", "prod-eu", "staging", "dev", "gke_project_us-central1_staging"}
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"prdo", []string{"prod"}},
+		{"prod-e", []string{"prod-eu", "prod"}},
+		{"stagign", []string{"staging"}},
+		{"gke_project_us-central1_stagin", []string{"gke_project_us-central1_staging"}},
+		{"something-else", nil},
+		{"prod", nil},
+	}
+	for _, tt := range tests {
+		got := Suggestions(tt.target, names)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("Suggestions(%q) diff=%s", tt.target, diff)
+		}
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	if got := DidYouMean(nil); got != "" {
+		t.Errorf("expected empty string; got=%q", got)
+	}
+	want := ` (did you mean "a", "b"?)`
+	if got := DidYouMean([]string{"a", "b"}); got != want {
+		t.Errorf("DidYouMean()=%q; want=%q", got, want)
+	}
+}
@@ -16,8 +16,14 @@ package cmdutil
 
 import (
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/env"
 )
 
 func HomeDir() string {
@@ -28,6 +34,223 @@ func HomeDir() string {
 	return home
 }
 
+// xdgStateDir returns the directory for the state files of kubectx following
+// the XDG base directory spec, or "" if neither $XDG_STATE_HOME nor
+// $XDG_CACHE_HOME is set to an absolute path.
+func xdgStateDir() string {
+	for _, k := range []string{"XDG_STATE_HOME", "XDG_CACHE_HOME"} {
+		// the spec says relative paths are invalid and should be ignored
+		if v := os.Getenv(k); v != "" && filepath.IsAbs(v) {
+			return filepath.Join(v, "kubectx")
+		}
+	}
+	return ""
+}
+
+// legacyStateFiles maps the legacy paths returned by StateFile to the paths
+// in the XDG state directory they're moved to when they're written.
+var legacyStateFiles = struct {
+	sync.Mutex
+	m map[string]string
+}{m: make(map[string]string)}
+
+// StateFile returns the path of the state file with the specified name in
+// the XDG state directory if one is set, or legacyPath otherwise. A file
+// found only at legacyPath keeps being read there, and is only moved to the
+// XDG state directory by WritableStateFile, so reading never writes to disk.
+func StateFile(name, legacyPath string) string {
+	dir := xdgStateDir()
+	if dir == "" {
+		return legacyPath
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return path
+	}
+	if _, err := os.Stat(legacyPath); os.IsNotExist(err) {
+		return path
+	}
+	legacyStateFiles.Lock()
+	legacyStateFiles.m[legacyPath] = path
+	legacyStateFiles.Unlock()
+	return legacyPath
+}
+
+// WritableStateFile returns the path to write the state file at path, as
+// returned by StateFile, to. A legacy file is moved to the XDG state
+// directory first. If it can't be moved, path keeps being used, so its data
+// isn't lost.
+func WritableStateFile(path string) string {
+	legacyStateFiles.Lock()
+	defer legacyStateFiles.Unlock()
+	target, ok := legacyStateFiles.m[path]
+	if !ok {
+		return path
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return path
+	}
+	if err := os.Rename(path, target); err != nil {
+		return path
+	}
+	delete(legacyStateFiles.m, path)
+	return target
+}
+
+// IsFuzzyMatching determines if substring matching of context names is
+// enabled with the environment.
+func IsFuzzyMatching() bool {
+	return isEnabled(env.EnvFuzzy)
+}
+
+// IsIgnoringCase determines if context names match regardless of case, when
+// enabled with the environment.
+func IsIgnoringCase() bool {
+	return isEnabled(env.EnvIgnoreCase)
+}
+
+// IsReadOnly determines if operations modifying the kubeconfig or the state
+// files are blocked with the environment.
+func IsReadOnly() bool {
+	return isEnabled(env.EnvReadOnly)
+}
+
+// ErrReadOnly is returned for the operations blocked by IsReadOnly.
+var ErrReadOnly = errors.New("not allowed in read-only mode (" + env.EnvReadOnly + " is set)")
+
+// IsInteractiveCurrent determines if the interactive mode should start with
+// the current context highlighted, when enabled with the environment.
+func IsInteractiveCurrent() bool {
+	return isEnabled(env.EnvInteractiveCurrent)
+}
+
+// SessionID returns the identifier of the shell session to remember the
+// namespaces of, or "" if session namespaces aren't enabled with the
+// environment or the session can't be identified. It's $KUBECTX_SESSION_ID,
+// or the session identifier the terminal or tmux sets.
+func SessionID() string {
+	if !isEnabled(env.EnvSessionNamespaces) {
+		return ""
+	}
+	for _, k := range []string{env.EnvSessionID, "TERM_SESSION_ID", "WT_SESSION"} {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+	if os.Getenv("TMUX") != "" {
+		return "tmux" + os.Getenv("TMUX_PANE")
+	}
+	return ""
+}
+
+// IsAutoSingle determines if listing the contexts should switch to the only
+// context instead, when enabled with the environment.
+func IsAutoSingle() bool {
+	return isEnabled(env.EnvAutoSingle)
+}
+
+// IsCrossProjectWarning determines if switching contexts should warn about
+// changing the cloud project, when enabled with the environment.
+func IsCrossProjectWarning() bool {
+	return isEnabled(env.EnvWarnCrossProject)
+}
+
+// isEnabled determines if the environment variable is set to a value other
+// than "0" or "false".
+func isEnabled(key string) bool {
+	switch os.Getenv(key) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
+
+// StripFlag removes all occurrences of the flag from argv and returns
+// whether the flag was present.
+func StripFlag(argv []string, flag string) ([]string, bool) {
+	var out []string
+	var found bool
+	for _, v := range argv {
+		if v == flag {
+			found = true
+			continue
+		}
+		out = append(out, v)
+	}
+	return out, found
+}
+
+// StripFlagValue removes the flag and the value following it from argv, and
+// returns the value, or "" if the flag isn't present. If the flag is given
+// more than once, the last value is returned.
+func StripFlagValue(argv []string, flag string) ([]string, string, error) {
+	var out []string
+	var value string
+	for i := 0; i < len(argv); i++ {
+		if argv[i] != flag {
+			out = append(out, argv[i])
+			continue
+		}
+		if i+1 >= len(argv) || argv[i+1] == "" {
+			return nil, "", errors.Errorf("'%s' needs an argument", flag)
+		}
+		i++
+		value = argv[i]
+	}
+	return out, value, nil
+}
+
+// WriteFileAtomic writes the data to a temporary file next to path, and
+// renames it to path, so readers never see a partially written file. It
+// creates missing parent directories.
+func WriteFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create parent directories")
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer os.Remove(f.Name()) // no-op after a successful rename
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return errors.Wrap(err, "failed to set file permissions")
+	}
+	return errors.Wrap(os.Rename(f.Name(), path), "failed to rename temporary file")
+}
+
+// MatchGlob determines if name matches the glob pattern, where "*" matches
+// any sequence of characters (including "/", which cluster ARNs contain) and
+// "?" matches a single character.
+func MatchGlob(pattern, name string) bool {
+	var re strings.Builder
+	re.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			re.WriteString(".*")
+		case '?':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	return regexp.MustCompile(re.String()).MatchString(name)
+}
+
+// ShellQuote quotes the value for use in POSIX shells.
+func ShellQuote(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", `'\''`) + "'"
+}
+
 // IsNotFoundErr determines if the underlying error is os.IsNotExist. Right now
 // errors from github.com/pkg/errors doesn't work with os.IsNotExist.
 func IsNotFoundErr(err error) bool {
@@ -15,8 +15,13 @@
 package cmdutil
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/ahmetb/kubectx/internal/testutil"
 )
 
@@ -78,3 +83,164 @@ func Test_homeDir(t *testing.T) {
 		})
 	}
 }
+
+func TestStripFlag(t *testing.T) {
+	got, found := StripFlag([]string{"--no-color", "a", "--no-color", "b"}, "--no-color")
+	if !found {
+		t.Fatal("expected flag to be found")
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, got); diff != "" {
+		t.Fatalf("StripFlag() diff=%s", diff)
+	}
+
+	got, found = StripFlag([]string{"a"}, "--no-color")
+	if found {
+		t.Fatal("expected flag to be not found")
+	}
+	if diff := cmp.Diff([]string{"a"}, got); diff != "" {
+		t.Fatalf("StripFlag() diff=%s", diff)
+	}
+}
+
+func TestStripFlagValue(t *testing.T) {
+	got, v, err := StripFlagValue([]string{"a", "--out", "foo", "b"}, "--out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "foo" {
+		t.Fatalf("expected value \"foo\"; got=\"%s\"", v)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, got); diff != "" {
+		t.Fatalf("StripFlagValue() diff=%s", diff)
+	}
+
+	if _, _, err := StripFlagValue([]string{"a", "--out"}, "--out"); err == nil {
+		t.Fatal("expected error for missing value")
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "atomic-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sub", "out")
+
+	for _, want := range []string{"first\n", "second\n"} {
+		if err := WriteFileAtomic(path, []byte(want)); err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != want {
+			t.Fatalf("expected=%q got=%q", want, string(b))
+		}
+	}
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected only the written file to be left; got %d files", len(files))
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := map[string]string{
+		"":          `''`,
+		"foo":       `'foo'`,
+		"a b":       `'a b'`,
+		"it's":      `'it'\''s'`,
+		"$HOME/`x`": "'$HOME/`x`'",
+	}
+	for in, want := range cases {
+		if got := ShellQuote(in); got != want {
+			t.Errorf("ShellQuote(%q)=%s, want=%s", in, got, want)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"prod", "prod", true},
+		{"prod", "prod-eu", false},
+		{"prod*", "prod-eu", true},
+		{"*prod*", "gke_p_us_prod-1", true},
+		{"arn:*:cluster/prod", "arn:aws:eks:us-east-1:1:cluster/prod", true},
+		{"prod-??", "prod-eu", true},
+		{"prod-?", "prod-eu", false},
+		{"a.b", "axb", false},
+		{"*", "", true},
+	}
+	for _, c := range cases {
+		if got := MatchGlob(c.pattern, c.name); got != c.want {
+			t.Errorf("MatchGlob(%q, %q)=%v, want=%v", c.pattern, c.name, got, c.want)
+		}
+	}
+}
+
+func TestStateFile(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "state-file-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	legacy := filepath.Join(dir, "legacy")
+	xdg := filepath.Join(dir, "xdg")
+
+	defer testutil.WithEnvVar("XDG_STATE_HOME", "")()
+	defer testutil.WithEnvVar("XDG_CACHE_HOME", "")()
+	if v := StateFile("foo", legacy); v != legacy {
+		t.Fatalf("without XDG dirs: got=%q, expected=%q", v, legacy)
+	}
+
+	defer testutil.WithEnvVar("XDG_CACHE_HOME", "relative/path")()
+	if v := StateFile("foo", legacy); v != legacy {
+		t.Fatalf("with relative XDG dir: got=%q, expected=%q", v, legacy)
+	}
+
+	defer testutil.WithEnvVar("XDG_STATE_HOME", xdg)()
+	expected := filepath.Join(xdg, "kubectx", "foo")
+	if v := StateFile("foo", legacy); v != expected {
+		t.Fatalf("with XDG_STATE_HOME: got=%q, expected=%q", v, expected)
+	}
+	if _, err := os.Stat(filepath.Dir(expected)); !os.IsNotExist(err) {
+		t.Fatal("state directory was created without any file to migrate")
+	}
+
+	if v := WritableStateFile(expected); v != expected {
+		t.Fatalf("writing new file: got=%q, expected=%q", v, expected)
+	}
+
+	if err := ioutil.WriteFile(legacy, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if v := StateFile("foo", legacy); v != legacy {
+		t.Fatalf("reading legacy file: got=%q, expected=%q", v, legacy)
+	}
+	if _, err := os.Stat(filepath.Dir(expected)); !os.IsNotExist(err) {
+		t.Fatal("legacy file was migrated by only reading it")
+	}
+	if v := WritableStateFile(legacy); v != expected {
+		t.Fatalf("writing legacy file: got=%q, expected=%q", v, expected)
+	}
+	if v := StateFile("foo", legacy); v != expected {
+		t.Fatalf("reading migrated file: got=%q, expected=%q", v, expected)
+	}
+	b, err := ioutil.ReadFile(expected)
+	if err != nil {
+		t.Fatalf("legacy file wasn't migrated: %v", err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("migrated file has wrong contents: %q", b)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Fatal("legacy file wasn't removed after migration")
+	}
+}
@@ -19,6 +19,95 @@ const (
 	// interactive context selection when fzf is installed.
 	EnvFZFIgnore = "KUBECTX_IGNORE_FZF"
 
+	// EnvFuzzy describes the environment variable to set to let context
+	// names to switch to match as a substring of a context name, unless
+	// --strict is given.
+	EnvFuzzy = `KUBECTX_FUZZY`
+
+	// EnvIgnoreCase describes the environment variable to set to let context
+	// names to switch to match regardless of case, if no name matches
+	// exactly, unless --strict is given.
+	EnvIgnoreCase = `KUBECTX_IGNORE_CASE`
+
+	// EnvAutoSingle describes the environment variable to set to make
+	// kubectx switch to the only context in kubeconfig, instead of listing
+	// it, when run without arguments in non-interactive mode.
+	EnvAutoSingle = `KUBECTX_AUTO_SINGLE`
+
+	// EnvWarnCrossProject describes the environment variable to set to warn
+	// when switching to a context of another cloud project or account.
+	EnvWarnCrossProject = `KUBECTX_WARN_CROSS_PROJECT`
+
+	// EnvPrevious describes the environment variable to set to override the
+	// context "kubectx -" switches to, instead of the previous context.
+	EnvPrevious = `KUBECTX_PREVIOUS`
+
+	// EnvInteractiveOrder describes the environment variable to set to
+	// choose how the contexts are ordered in the interactive mode, as a
+	// comma-separated list of "order" (the user-defined order) and "recent"
+	// (the recently used contexts). The remaining contexts are listed by name.
+	EnvInteractiveOrder = `KUBECTX_INTERACTIVE_ORDER`
+
+	// EnvDangerPattern describes the environment variable to set to the
+	// regular expression matching the names of the contexts to confirm
+	// switching to, instead of "prod|production". Empty disables it.
+	EnvDangerPattern = `KUBECTX_DANGER_PATTERN`
+
+	// EnvKubensDangerPattern describes the environment variable to set to
+	// the regular expression matching the names of the namespaces to confirm
+	// switching to, instead of "^kube-system$|-prod$". Empty disables it.
+	EnvKubensDangerPattern = `KUBENS_DANGER_PATTERN`
+
+	// EnvReadOnly describes the environment variable to set to block the
+	// operations of kubectx and kubens that modify the kubeconfig or their
+	// state files, such as on shared machines.
+	EnvReadOnly = `KUBECTX_READONLY`
+
+	// EnvInteractiveCurrent describes the environment variable to set to
+	// start the interactive mode of kubectx with the current context
+	// highlighted, instead of the first one.
+	EnvInteractiveCurrent = `KUBECTX_INTERACTIVE_CURRENT`
+
+	// EnvSessionNamespaces describes the environment variable to set to
+	// remember the namespace last used in each context per shell session,
+	// and restore it when switching to the context in the same session.
+	EnvSessionNamespaces = `KUBECTX_SESSION_NAMESPACES`
+
+	// EnvSessionID describes the environment variable to set to identify the
+	// shell session for EnvSessionNamespaces, if the terminal doesn't.
+	EnvSessionID = `KUBECTX_SESSION_ID`
+
+	// EnvPostRenameHook describes the environment variable to set to a
+	// command to run after kubectx renames a context, with the old and the
+	// new name as its arguments.
+	EnvPostRenameHook = `KUBECTX_POST_RENAME_HOOK`
+
+	// EnvPostDeleteHook describes the environment variable to set to a
+	// command to run after kubectx deletes a context, with its name as the
+	// argument.
+	EnvPostDeleteHook = `KUBECTX_POST_DELETE_HOOK`
+
+	// EnvDisplayCmd describes the environment variable to set to a command
+	// transforming context names for display in the list and the interactive
+	// mode. Each name is written to its stdin, and the line it prints is
+	// shown instead.
+	EnvDisplayCmd = `KUBECTX_DISPLAY_CMD`
+
+	// EnvAuditLog describes the environment variable to set to the path of
+	// a file kubectx appends a JSON line to for every context it switches
+	// to, renames, deletes or unsets.
+	EnvAuditLog = `KUBECTX_AUDIT_LOG`
+
+	// EnvHealthTimeout describes the environment variable to set to change
+	// the default timeout of probing each API server for "kubectx --health"
+	// and "kubectx --only-reachable", and of listing the namespaces of each
+	// context for "kubens --all-contexts", as a Go duration (e.g. "2s").
+	EnvHealthTimeout = `KUBECTX_HEALTH_TIMEOUT`
+
+	// EnvHealthConcurrency describes the environment variable to set to
+	// change the default number of API servers probed at the same time.
+	EnvHealthConcurrency = `KUBECTX_HEALTH_CONCURRENCY`
+
 	// EnvNoColor describes the environment variable to disable color usage
 	// when printing current context in a list.
 	EnvNoColor = `NO_COLOR`
@@ -27,6 +116,35 @@ const (
 	// color usage to show current context in a list.
 	EnvForceColor = `_KUBECTX_FORCE_COLOR`
 
+	// EnvDisplayNames describes the "internal" environment variable making
+	// the list print each context name before its display name, separated by
+	// a tab, for the interactive mode to show the display names but return
+	// the context names.
+	EnvDisplayNames = `_KUBECTX_DISPLAY_NAMES`
+
+	// EnvKubensRetries describes the environment variable to configure how
+	// many times kubens retries Kubernetes API calls failing with transient
+	// errors.
+	EnvKubensRetries = `KUBENS_RETRIES`
+
+	// EnvKubensNamespace describes the environment variable printed by
+	// "kubens --print-env" for shell wrappers of kubectl to select the
+	// namespace of a shell without changing the kubeconfig.
+	EnvKubensNamespace = `KUBENS_NAMESPACE`
+
+	// EnvBackupDir describes the environment variable to set to back up
+	// kubeconfig files to the directory before modifying them.
+	EnvBackupDir = `KUBECTX_BACKUP_DIR`
+
+	// EnvBackupKeep describes the environment variable to configure how
+	// many backups of each kubeconfig file are kept.
+	EnvBackupKeep = `KUBECTX_BACKUP_KEEP`
+
+	// EnvConfig describes the environment variable to set to read the
+	// defaults of kubectx and kubens from a config file other than
+	// $XDG_CONFIG_HOME/kubectx/config.yaml.
+	EnvConfig = `KUBECTX_CONFIG`
+
 	// EnvDebug describes the internal environment variable for more verbose logging.
 	EnvDebug = `DEBUG`
 )
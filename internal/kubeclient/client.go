@@ -0,0 +1,42 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeclient
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// NewClientSet initializes a client for the cluster of the specified
+// context.
+func NewClientSet(kc *kubeconfig.Kubeconfig, ctx string) (*kubernetes.Clientset, error) {
+	b, err := kc.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert in-memory kubeconfig to yaml")
+	}
+	rawCfg, err := clientcmd.Load(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load config")
+	}
+	cfg, err := clientcmd.NewNonInteractiveClientConfig(*rawCfg, ctx, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize config")
+	}
+	return kubernetes.NewForConfig(cfg)
+}
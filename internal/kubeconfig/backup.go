@@ -0,0 +1,120 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"github.com/ahmetb/kubectx/internal/env"
+)
+
+const defaultBackupKeep = 10
+
+// backupsEnabled indicates backups were requested even if the backup
+// directory is not set.
+var backupsEnabled bool
+
+// EnableBackups makes the kubeconfig files be backed up before they're
+// modified, even if $KUBECTX_BACKUP_DIR is not set.
+func EnableBackups() { backupsEnabled = true }
+
+// backupDir returns the directory to back up kubeconfig files to, or "" if
+// backups are disabled.
+func backupDir() string {
+	if v := os.Getenv(env.EnvBackupDir); v != "" {
+		return v
+	}
+	if !backupsEnabled {
+		return ""
+	}
+	return filepath.Join(cmdutil.HomeDir(), ".kube", "kubectx-backups")
+}
+
+// backupKeep returns the number of backups to keep for each file.
+func backupKeep() (int, error) {
+	v := os.Getenv(env.EnvBackupKeep)
+	if v == "" {
+		return defaultBackupKeep, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, errors.Errorf("invalid %s value %q, must be a positive number", env.EnvBackupKeep, v)
+	}
+	return n, nil
+}
+
+// backup copies the contents of the file to a timestamped file in the
+// backup directory, and removes its oldest backups beyond the retention
+// limit. It's a no-op if backups are disabled.
+func backup(f *os.File) error {
+	dir := backupDir()
+	if dir == "" {
+		return nil
+	}
+	keep, err := backupKeep()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create backup directory")
+	}
+
+	prefix := filepath.Base(f.Name()) + "."
+	path := filepath.Join(dir, prefix+time.Now().UTC().Format("20060102T150405.000000000Z"))
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create backup file")
+	}
+	defer out.Close()
+	if _, err := f.Seek(0, 0); err != nil {
+		return errors.Wrap(err, "failed to seek in file")
+	}
+	if _, err := io.Copy(out, f); err != nil {
+		return errors.Wrap(err, "failed to write backup file")
+	}
+	return pruneBackups(dir, prefix, keep)
+}
+
+// pruneBackups removes all but the newest keep backups with the prefix.
+func pruneBackups(dir, prefix string, keep int) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to list backups")
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names) // timestamps sort chronologically
+	for len(names) > keep {
+		if err := os.Remove(filepath.Join(dir, names[0])); err != nil {
+			return errors.Wrap(err, "failed to remove old backup")
+		}
+		names = names[1:]
+	}
+	return nil
+}
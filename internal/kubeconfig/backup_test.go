@@ -0,0 +1,97 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestKubeconfigFile_Reset_backup(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "backup-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	backups := filepath.Join(dir, "backups")
+	defer testutil.WithEnvVar("KUBECTX_BACKUP_DIR", backups)()
+	defer testutil.WithEnvVar("KUBECTX_BACKUP_KEEP", "2")()
+
+	path := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(path, []byte("v0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	kf := &kubeconfigFile{f}
+
+	for _, v := range []string{"v1", "v2", "v3"} {
+		if err := kf.Reset(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := kf.WriteString(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := ioutil.ReadDir(backups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 backups to be kept; got=%d", len(entries))
+	}
+	for i, expected := range []string{"v1", "v2"} {
+		b, err := ioutil.ReadFile(filepath.Join(backups, entries[i].Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != expected {
+			t.Fatalf("backup %d has contents %q; expected=%q", i, b, expected)
+		}
+	}
+}
+
+func TestKubeconfigFile_Reset_noBackup(t *testing.T) {
+	defer testutil.WithEnvVar("KUBECTX_BACKUP_DIR", "")()
+	path, cleanup := testutil.TempFile(t, "v0")
+	defer cleanup()
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if v := backupDir(); v != "" {
+		t.Fatalf("expected backups to be disabled; got dir=%q", v)
+	}
+	if err := (&kubeconfigFile{f}).Reset(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_backupKeep_invalid(t *testing.T) {
+	defer testutil.WithEnvVar("KUBECTX_BACKUP_KEEP", "0")()
+	if _, err := backupKeep(); err == nil {
+		t.Fatal("expected error for invalid retention")
+	}
+}
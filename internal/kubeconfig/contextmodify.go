@@ -20,64 +20,128 @@ import (
 )
 
 func (k *Kubeconfig) DeleteContextEntry(deleteName string) error {
-	contexts, err := k.contextsNode()
+	files, nodes, err := k.contextsNodes()
 	if err != nil {
 		return err
 	}
 
-	i := -1
-	for j, ctxNode := range contexts.Content {
-		nameNode := valueOf(ctxNode, "name")
-		if nameNode != nil && nameNode.Kind == yaml.ScalarNode && nameNode.Value == deleteName {
-			i = j
-			break
+	for n, contexts := range nodes {
+		i := -1
+		for j, ctxNode := range contexts.Content {
+			nameNode := valueOf(ctxNode, "name")
+			if nameNode != nil && nameNode.Kind == yaml.ScalarNode && nameNode.Value == deleteName {
+				i = j
+				break
+			}
+		}
+		if i >= 0 {
+			copy(contexts.Content[i:], contexts.Content[i+1:])
+			contexts.Content[len(contexts.Content)-1] = nil
+			contexts.Content = contexts.Content[:len(contexts.Content)-1]
+			files[n].modified = true
+			return nil
 		}
-	}
-	if i >= 0 {
-		copy(contexts.Content[i:], contexts.Content[i+1:])
-		contexts.Content[len(contexts.Content)-1] = nil
-		contexts.Content = contexts.Content[:len(contexts.Content)-1]
 	}
 	return nil
 }
 
+// ModifyCurrentContext sets the current-context. Similar to kubectl, the
+// value is written to the first file that has a current-context set, or the
+// first file if none of them do, unless another file is set with SetWriteTo.
 func (k *Kubeconfig) ModifyCurrentContext(name string) error {
-	currentCtxNode := valueOf(k.rootNode, "current-context")
+	def := k.files[0]
+	for _, cf := range k.files {
+		if v := valueOf(cf.rootNode, "current-context"); v != nil && v.Value != "" {
+			def = cf
+			break
+		}
+	}
+	target, err := k.writeTarget(def)
+	if err != nil {
+		return err
+	}
+	for _, cf := range k.files {
+		if cf == target {
+			break
+		}
+		if v := valueOf(cf.rootNode, "current-context"); v != nil && v.Value != "" {
+			return errors.Errorf("current-context set in \"%s\" would take precedence over the file to write to", cf.path())
+		}
+	}
+	target.modified = true
+
+	currentCtxNode := valueOf(target.rootNode, "current-context")
 	if currentCtxNode != nil {
 		currentCtxNode.Value = name
 		return nil
 	}
 
 	// if current-context field doesn't exist, create new field
-	keyNode := &yaml.Node{
-		Kind:  yaml.ScalarNode,
-		Value: "current-context",
-		Tag:   "!!str"}
-	valueNode := &yaml.Node{
-		Kind:  yaml.ScalarNode,
-		Value: name,
-		Tag:   "!!str"}
-	k.rootNode.Content = append(k.rootNode.Content, keyNode, valueNode)
+	setValue(target.rootNode, "current-context", scalarNode(name))
 	return nil
 }
 
 func (k *Kubeconfig) ModifyContextName(old, new string) error {
-	contexts, err := k.contextsNode()
+	files, nodes, err := k.contextsNodes()
 	if err != nil {
 		return err
 	}
 
-	var changed bool
-	for _, contextNode := range contexts.Content {
-		nameNode := valueOf(contextNode, "name")
-		if nameNode.Kind == yaml.ScalarNode && nameNode.Value == old {
-			nameNode.Value = new
-			changed = true
-			break
+	for i, contexts := range nodes {
+		for _, contextNode := range contexts.Content {
+			nameNode := valueOf(contextNode, "name")
+			if nameNode != nil && nameNode.Kind == yaml.ScalarNode && nameNode.Value == old {
+				nameNode.Value = new
+				files[i].modified = true
+				return nil
+			}
 		}
 	}
-	if !changed {
-		return errors.New("no changes were made")
+	return errors.New("no changes were made")
+}
+
+// ContextEntry returns the context entry with the specified name as a YAML
+// document, so it can be restored later with AddContextEntry.
+func (k *Kubeconfig) ContextEntry(name string) ([]byte, error) {
+	_, ctxNode, err := k.contextNode(name)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(ctxNode)
+}
+
+// AddContextEntry adds a context entry given as a YAML document to the
+// first file, or the file set with SetWriteTo.
+func (k *Kubeconfig) AddContextEntry(entry []byte) error {
+	var v yaml.Node
+	if err := yaml.Unmarshal(entry, &v); err != nil {
+		return errors.Wrap(err, "failed to decode context entry")
+	}
+	if len(v.Content) == 0 || v.Content[0].Kind != yaml.MappingNode {
+		return errors.New("context entry is not a map")
+	}
+	ctxNode := v.Content[0]
+	nameNode := valueOf(ctxNode, "name")
+	if nameNode == nil || nameNode.Value == "" {
+		return errors.New("context entry has no name")
+	}
+	if k.ContextExists(nameNode.Value) {
+		return errors.Errorf("context with name \"%s\" already exists", nameNode.Value)
+	}
+
+	cf, err := k.writeTarget(k.files[0])
+	if err != nil {
+		return err
+	}
+	contexts, err := cf.contextsNode()
+	if err != nil {
+		return err
+	}
+	if contexts == nil {
+		contexts = &yaml.Node{Kind: yaml.SequenceNode}
+		setValue(cf.rootNode, "contexts", contexts)
 	}
+	contexts.Content = append(contexts.Content, ctxNode)
+	cf.modified = true
 	return nil
 }
@@ -114,6 +114,60 @@ current-context: foo
 	}
 }
 
+func TestKubeconfig_ModifyCurrentContext_multipleFiles(t *testing.T) {
+	// file "a" sets current-context, file "b" defines the context switched to
+	a := testutil.KC().WithCurrentCtx("a").WithCtxs(testutil.Ctx("a")).ToYAML(t)
+	b := testutil.KC().WithCtxs(testutil.Ctx("b")).ToYAML(t)
+	noCurrent := testutil.KC().WithCtxs(testutil.Ctx("a")).ToYAML(t)
+	tests := []struct {
+		name    string
+		files   []string
+		written int      // index of the only file expected to be written
+		ctxs    []string // contexts of the written file, unchanged
+	}{
+		{name: "current-context in the first file", files: []string{a, b}, written: 0, ctxs: []string{"a"}},
+		{name: "current-context in the second file", files: []string{b, a}, written: 1, ctxs: []string{"a"}},
+		{name: "no current-context writes the first file", files: []string{b, noCurrent}, written: 0, ctxs: []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := WithMockKubeconfigLoaders(tt.files...)
+			kc := new(Kubeconfig).WithLoader(test)
+			defer kc.Close()
+			if err := kc.Parse(); err != nil {
+				t.Fatal(err)
+			}
+			if err := kc.ModifyCurrentContext("b"); err != nil {
+				t.Fatal(err)
+			}
+			if err := kc.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := range tt.files {
+				out := test.Output(i)
+				if i != tt.written {
+					if out != "" {
+						t.Errorf("file %d was written: %s", i, out)
+					}
+					continue
+				}
+				got := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(out))
+				if err := got.Parse(); err != nil {
+					t.Fatal(err)
+				}
+				if v := got.GetCurrentContext(); v != "b" {
+					t.Errorf("current-context of file %d=%q; expected=%q", i, v, "b")
+				}
+				// no context is copied from the other file
+				if diff := cmp.Diff(tt.ctxs, got.ContextNames()); diff != "" {
+					t.Errorf("contexts of file %d diff: %s", i, diff)
+				}
+			}
+		})
+	}
+}
+
 func TestKubeconfig_ModifyContextName_noContextsEntryError(t *testing.T) {
 	// no context entries
 	test := WithMockKubeconfigLoader(`a: b`)
@@ -178,3 +232,52 @@ func TestKubeconfig_ModifyContextName(t *testing.T) {
 		t.Fatalf("diff: %s", diff)
 	}
 }
+
+func TestKubeconfig_ContextEntry_roundTrip(t *testing.T) {
+	in := testutil.KC().WithCtxs(
+		testutil.Ctx("c1"),
+		testutil.Ctx("c2").Ns("ns")).ToYAML(t)
+	test := WithMockKubeconfigLoader(in)
+	kc := new(Kubeconfig).WithLoader(test)
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := kc.ContextEntry("c2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.AddContextEntry(entry); err == nil {
+		t.Fatal("supposed to fail if the context already exists")
+	}
+	if err := kc.DeleteContextEntry("c2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.AddContextEntry(entry); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(in, test.Output()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestKubeconfig_AddContextEntry_noContextsEntry(t *testing.T) {
+	test := WithMockKubeconfigLoader(`current-context: c1`)
+	kc := new(Kubeconfig).WithLoader(test)
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.AddContextEntry([]byte("name: c1\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "current-context: c1\ncontexts:\n    - name: c1\n"
+	if diff := cmp.Diff(expected, test.Output()); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -15,52 +15,153 @@
 package kubeconfig
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
 
-func (k *Kubeconfig) contextsNode() (*yaml.Node, error) {
-	contexts := valueOf(k.rootNode, "contexts")
+// contextsNode returns the "contexts" entry of the file, or nil if the file
+// has no such entry.
+func (cf *configFile) contextsNode() (*yaml.Node, error) {
+	contexts := valueOf(cf.rootNode, "contexts")
 	if contexts == nil {
-		return nil, errors.New("\"contexts\" entry is nil")
+		return nil, nil
 	} else if contexts.Kind != yaml.SequenceNode {
 		return nil, errors.New("\"contexts\" is not a sequence node")
 	}
 	return contexts, nil
 }
 
-func (k *Kubeconfig) contextNode(name string) (*yaml.Node, error) {
-	contexts, err := k.contextsNode()
+// contextsNodes returns the "contexts" entries of the files that have one
+// along with the files, or an error if none of the files have contexts.
+func (k *Kubeconfig) contextsNodes() ([]*configFile, []*yaml.Node, error) {
+	var files []*configFile
+	var nodes []*yaml.Node
+	for _, cf := range k.files {
+		contexts, err := cf.contextsNode()
+		if err != nil {
+			return nil, nil, err
+		} else if contexts == nil {
+			continue
+		}
+		files = append(files, cf)
+		nodes = append(nodes, contexts)
+	}
+	if len(nodes) == 0 {
+		return nil, nil, errors.New("\"contexts\" entry is nil")
+	}
+	return files, nodes, nil
+}
+
+// contextNode finds the context entry with the specified name, and the
+// file that defines it.
+func (k *Kubeconfig) contextNode(name string) (*configFile, *yaml.Node, error) {
+	files, nodes, err := k.contextsNodes()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	for _, contextNode := range contexts.Content {
-		nameNode := valueOf(contextNode, "name")
-		if nameNode.Kind == yaml.ScalarNode && nameNode.Value == name {
-			return contextNode, nil
+	for i, contexts := range nodes {
+		for _, contextNode := range contexts.Content {
+			nameNode := valueOf(contextNode, "name")
+			if nameNode != nil && nameNode.Kind == yaml.ScalarNode && nameNode.Value == name {
+				return files[i], contextNode, nil
+			}
 		}
 	}
-	return nil, errors.Errorf("context with name \"%s\" not found", name)
+	return nil, nil, errors.Errorf("context with name \"%s\" not found", name)
 }
 
 func (k *Kubeconfig) ContextNames() []string {
-	contexts := valueOf(k.rootNode, "contexts")
-	if contexts == nil {
-		return nil
+	return k.entryNames("contexts")
+}
+
+// UserNames returns the names of the users defined in the kubeconfig files.
+func (k *Kubeconfig) UserNames() []string {
+	return k.entryNames("users")
+}
+
+// ClusterNames returns the names of the clusters defined in the kubeconfig
+// files.
+func (k *Kubeconfig) ClusterNames() []string {
+	return k.entryNames("clusters")
+}
+
+// ClusterServer returns the server address of the cluster, or empty if the
+// cluster doesn't exist or has no server.
+func (k *Kubeconfig) ClusterServer(name string) string {
+	_, entry := k.namedEntry("clusters", name)
+	if entry == nil {
+		return ""
 	}
-	if contexts.Kind != yaml.SequenceNode {
-		return nil
+	body := valueOf(entry, "cluster")
+	if body == nil {
+		return ""
+	}
+	if v := valueOf(body, "server"); v != nil {
+		return v.Value
+	}
+	return ""
+}
+
+// ContextsWithServer returns the names of the contexts whose cluster has the
+// server address. Addresses are compared ignoring case, a trailing '/' and
+// the default port of https. A server without a scheme (e.g.
+// "example.com:6443") matches addresses with any scheme.
+func (k *Kubeconfig) ContextsWithServer(server string) ([]string, error) {
+	want := normalizeServer(server)
+	anyScheme := !strings.Contains(want, "://")
+	var out []string
+	for _, name := range k.ContextNames() {
+		cluster, err := k.ClusterOfContext(name)
+		if err != nil {
+			return nil, err
+		}
+		got := normalizeServer(k.ClusterServer(cluster))
+		if got == "" {
+			continue
+		}
+		if anyScheme {
+			if i := strings.Index(got, "://"); i >= 0 {
+				got = got[i+len("://"):]
+			}
+		}
+		if got == want {
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
+
+func normalizeServer(s string) string {
+	s = strings.TrimRight(strings.ToLower(strings.TrimSpace(s)), "/")
+	if strings.HasPrefix(s, "https://") {
+		s = strings.TrimSuffix(s, ":443")
 	}
+	return s
+}
 
-	var ctxNames []string
-	for _, ctx := range contexts.Content {
-		nameVal := valueOf(ctx, "name")
-		if nameVal != nil {
-			ctxNames = append(ctxNames, nameVal.Value)
+// entryNames returns the names of the entries in the key (e.g. "contexts")
+// of the files, in the order they're defined. Names defined in more than
+// one file are only returned once.
+func (k *Kubeconfig) entryNames(key string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, cf := range k.files {
+		entries := valueOf(cf.rootNode, key)
+		if entries == nil || entries.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, entry := range entries.Content {
+			nameVal := valueOf(entry, "name")
+			if nameVal != nil && !seen[nameVal.Value] {
+				seen[nameVal.Value] = true
+				names = append(names, nameVal.Value)
+			}
 		}
 	}
-	return ctxNames
+	return names
 }
 
 func (k *Kubeconfig) ContextExists(name string) bool {
@@ -84,3 +185,31 @@ func valueOf(mapNode *yaml.Node, key string) *yaml.Node {
 	}
 	return nil
 }
+
+// ClusterOfContext returns the name of the cluster the context refers to,
+// or empty if it doesn't refer to any.
+func (k *Kubeconfig) ClusterOfContext(name string) (string, error) {
+	return k.contextField(name, "cluster")
+}
+
+// UserOfContext returns the name of the user the context refers to, or empty
+// if it doesn't refer to any.
+func (k *Kubeconfig) UserOfContext(name string) (string, error) {
+	return k.contextField(name, "user")
+}
+
+func (k *Kubeconfig) contextField(name, field string) (string, error) {
+	_, ctx, err := k.contextNode(name)
+	if err != nil {
+		return "", err
+	}
+	ctxBody := valueOf(ctx, "context")
+	if ctxBody == nil {
+		return "", nil
+	}
+	v := valueOf(ctxBody, field)
+	if v == nil {
+		return "", nil
+	}
+	return v.Value, nil
+}
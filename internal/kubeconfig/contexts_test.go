@@ -87,3 +87,105 @@ func TestKubeconfig_CheckContextExists(t *testing.T) {
 		t.Fatal("c3 does not exist; but reported true")
 	}
 }
+
+func TestKubeconfig_ClusterAndUserOfContext(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(`contexts:
+- name: c1
+  context:
+    cluster: cluster1
+    user: user1
+- name: c2`))
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		ctx, cluster, user string
+	}{{"c1", "cluster1", "user1"}, {"c2", "", ""}} {
+		cluster, err := kc.ClusterOfContext(tt.ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		user, err := kc.UserOfContext(tt.ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cluster != tt.cluster || user != tt.user {
+			t.Fatalf("%s: got cluster=%q user=%q; expected cluster=%q user=%q",
+				tt.ctx, cluster, user, tt.cluster, tt.user)
+		}
+	}
+
+	if _, err := kc.ClusterOfContext("c3"); err == nil {
+		t.Fatal("expected error for missing context")
+	}
+}
+
+func TestKubeconfig_UserAndClusterNames(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(`clusters:
+- name: c1
+  cluster:
+    server: https://c1.example.com
+- name: c2
+users:
+- name: u1
+- name: u2`))
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"u1", "u2"}, kc.UserNames()); diff != "" {
+		t.Fatalf("UserNames() diff=%s", diff)
+	}
+	if diff := cmp.Diff([]string{"c1", "c2"}, kc.ClusterNames()); diff != "" {
+		t.Fatalf("ClusterNames() diff=%s", diff)
+	}
+	for name, want := range map[string]string{"c1": "https://c1.example.com", "c2": "", "c3": ""} {
+		if got := kc.ClusterServer(name); got != want {
+			t.Errorf("ClusterServer(%q)=%q; expected=%q", name, got, want)
+		}
+	}
+}
+
+func TestKubeconfig_ContextsWithServer(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(`contexts:
+- name: a
+  context:
+    cluster: cluster1
+- name: a-admin
+  context:
+    cluster: cluster1
+- name: b
+  context:
+    cluster: cluster2
+- name: c
+clusters:
+- name: cluster1
+  cluster:
+    server: https://Example.com:443/
+- name: cluster2
+  cluster:
+    server: http://10.0.0.1:6443`))
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		server string
+		want   []string
+	}{
+		{"https://example.com", []string{"a", "a-admin"}},
+		{"example.com", []string{"a", "a-admin"}},
+		{"http://10.0.0.1:6443/", []string{"b"}},
+		{"10.0.0.1:6443", []string{"b"}},
+		{"https://10.0.0.1:6443", nil},
+		{"10.0.0.1", nil},
+	} {
+		got, err := kc.ContextsWithServer(tt.server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("ContextsWithServer(%q) diff=%s", tt.server, diff)
+		}
+	}
+}
@@ -14,18 +14,23 @@
 
 package kubeconfig
 
-// GetCurrentContext returns "current-context" value in given
-// kubeconfig object Node, or returns "" if not found.
+// GetCurrentContext returns the first "current-context" value set in the
+// kubeconfig files, or returns "" if not found.
 func (k *Kubeconfig) GetCurrentContext() string {
-	v := valueOf(k.rootNode, "current-context")
-	if v == nil {
-		return ""
+	for _, cf := range k.files {
+		if v := valueOf(cf.rootNode, "current-context"); v != nil && v.Value != "" {
+			return v.Value
+		}
 	}
-	return v.Value
+	return ""
 }
 
 func (k *Kubeconfig) UnsetCurrentContext() error {
-	curCtxValNode := valueOf(k.rootNode, "current-context")
-	curCtxValNode.Value = ""
+	for _, cf := range k.files {
+		if v := valueOf(cf.rootNode, "current-context"); v != nil && v.Value != "" {
+			v.Value = ""
+			cf.modified = true
+		}
+	}
 	return nil
 }
@@ -0,0 +1,122 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// extensionNode returns the entry of the named extension in
+// preferences.extensions of the file, or nil if it doesn't have one.
+func (cf *configFile) extensionNode(name string) *yaml.Node {
+	prefs := valueOf(cf.rootNode, "preferences")
+	if prefs == nil || prefs.Kind != yaml.MappingNode {
+		return nil
+	}
+	exts := valueOf(prefs, "extensions")
+	if exts == nil || exts.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, e := range exts.Content {
+		if n := valueOf(e, "name"); n != nil && n.Value == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// Extension decodes the named extension in the preferences of the first file
+// that has one into v, a pointer to a struct, and returns false if none of the
+// files have it. Unknown fields and invalid values are left unset and reported
+// in the error, while the valid fields are still decoded, so callers can only
+// warn about it.
+func (k *Kubeconfig) Extension(name string, v interface{}) (bool, error) {
+	for _, cf := range k.files {
+		e := cf.extensionNode(name)
+		if e == nil {
+			continue
+		}
+		body := valueOf(e, "extension")
+		if body == nil {
+			return true, nil
+		}
+		if problems := decodeFields(body, v); len(problems) > 0 {
+			return true, errors.Errorf("invalid extension \"%s\" in kubeconfig preferences: %s",
+				name, strings.Join(problems, "; "))
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// decodeFields decodes the fields of the mapping node into the struct v points
+// to one by one, so the unknown and invalid ones are skipped, and describes
+// the problems with them.
+func decodeFields(n *yaml.Node, v interface{}) []string {
+	if n.Kind != yaml.MappingNode {
+		return []string{fmt.Sprintf("line %d: not a map", n.Line)}
+	}
+	t := reflect.TypeOf(v).Elem()
+	known := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if key == "" {
+			key = strings.ToLower(f.Name)
+		}
+		known[key] = true
+	}
+
+	var out []string
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k := n.Content[i]
+		if !known[k.Value] {
+			out = append(out, fmt.Sprintf("line %d: unknown field \"%s\"", k.Line, k.Value))
+			continue
+		}
+		field := &yaml.Node{Kind: yaml.MappingNode, Content: n.Content[i : i+2]}
+		// decoding a failing field sets it anyway, so it's tried on a copy first
+		if err := field.Decode(reflect.New(t).Interface()); err != nil {
+			var typeErr *yaml.TypeError
+			if errors.As(err, &typeErr) {
+				out = append(out, typeErr.Errors...)
+			} else {
+				out = append(out, err.Error())
+			}
+			continue
+		}
+		if err := field.Decode(v); err != nil {
+			out = append(out, err.Error())
+		}
+	}
+	return out
+}
+
+// DefaultExtension is like Extension, for the kubeconfig files of the
+// DefaultLoader. Kubeconfig files that are missing or can't be parsed have no
+// extensions, as the commands using them report such errors.
+func DefaultExtension(name string, v interface{}) (bool, error) {
+	kc := new(Kubeconfig).WithLoader(DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		return false, nil
+	}
+	return kc.Extension(name, v)
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testExtension struct {
+	Fuzzy *bool  `yaml:"fuzzy"`
+	Order string `yaml:"order"`
+}
+
+func TestKubeconfig_Extension(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(`preferences:
+  extensions:
+  - name: other
+    extension:
+      order: wrong
+  - name: kubectx
+    extension:
+      order: recent
+      unknown: 1
+`))
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	var v testExtension
+	ok, err := kc.Extension("kubectx", &v)
+	if err == nil || !strings.Contains(err.Error(), `line 9: unknown field "unknown"`) {
+		t.Fatalf("err=%v; want the unknown field reported", err)
+	}
+	if !ok {
+		t.Fatal("expected extension to be found")
+	}
+	if diff := cmp.Diff(testExtension{Order: "recent"}, v); diff != "" {
+		t.Fatalf("valid fields not decoded, diff=%s", diff)
+	}
+	if ok, err := kc.Extension("missing", &v); ok || err != nil {
+		t.Fatalf("missing extension: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestKubeconfig_Extension_invalid(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(`preferences:
+  extensions:
+  - name: kubectx
+    extension:
+      fuzzy: [1]
+      order: recent
+`))
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	var v testExtension
+	if _, err := kc.Extension("kubectx", &v); err == nil {
+		t.Fatal("expected error for invalid extension")
+	}
+	if diff := cmp.Diff(testExtension{Order: "recent"}, v); diff != "" {
+		t.Fatalf("valid fields not decoded, diff=%s", diff)
+	}
+}
@@ -37,3 +37,24 @@ func (t *MockKubeconfigLoader) Output() string { return t.out.String() }
 func WithMockKubeconfigLoader(kubecfg string) *MockKubeconfigLoader {
 	return &MockKubeconfigLoader{in: strings.NewReader(kubecfg)}
 }
+
+type MockMultiKubeconfigLoader struct {
+	files []*MockKubeconfigLoader
+}
+
+func (t *MockMultiKubeconfigLoader) Load() ([]ReadWriteResetCloser, error) {
+	var out []ReadWriteResetCloser
+	for _, f := range t.files {
+		out = append(out, ReadWriteResetCloser(f))
+	}
+	return out, nil
+}
+func (t *MockMultiKubeconfigLoader) Output(i int) string { return t.files[i].Output() }
+
+func WithMockKubeconfigLoaders(kubecfgs ...string) *MockMultiKubeconfigLoader {
+	m := new(MockMultiKubeconfigLoader)
+	for _, v := range kubecfgs {
+		m.files = append(m.files, WithMockKubeconfigLoader(v))
+	}
+	return m
+}
@@ -35,8 +35,15 @@ type Loader interface {
 type Kubeconfig struct {
 	loader Loader
 
+	files []*configFile
+}
+
+// configFile is a parsed kubeconfig file. Entries defined in earlier files
+// take precedence over the ones in later files, similar to kubectl.
+type configFile struct {
 	f        ReadWriteResetCloser
 	rootNode *yaml.Node
+	modified bool
 }
 
 func (k *Kubeconfig) WithLoader(l Loader) *Kubeconfig {
@@ -45,10 +52,13 @@ func (k *Kubeconfig) WithLoader(l Loader) *Kubeconfig {
 }
 
 func (k *Kubeconfig) Close() error {
-	if k.f == nil {
-		return nil
+	var err error
+	for _, cf := range k.files {
+		if cerr := cf.f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
 	}
-	return k.f.Close()
+	return err
 }
 
 func (k *Kubeconfig) Parse() error {
@@ -57,30 +67,102 @@ func (k *Kubeconfig) Parse() error {
 		return errors.Wrap(err, "failed to load")
 	}
 
-	// TODO since we don't support multiple kubeconfig files at the moment, there's just 1 file
-	f := files[0]
+	for _, f := range files {
+		cf := &configFile{f: f}
+		k.files = append(k.files, cf)
 
-	k.f = f
-	var v yaml.Node
-	if err := yaml.NewDecoder(f).Decode(&v); err != nil {
-		return errors.Wrap(err, "failed to decode")
-	}
-	k.rootNode = v.Content[0]
-	if k.rootNode.Kind != yaml.MappingNode {
-		return errors.New("kubeconfig file is not a map document")
+		var v yaml.Node
+		if err := yaml.NewDecoder(f).Decode(&v); err != nil && err != io.EOF {
+			return errors.Wrap(err, "failed to decode")
+		}
+		if len(v.Content) == 0 {
+			// empty files are tolerated like kubectl does, as an empty config
+			cf.rootNode = &yaml.Node{Kind: yaml.MappingNode}
+			continue
+		}
+		cf.rootNode = v.Content[0]
+		if cf.rootNode.Kind != yaml.MappingNode {
+			return errors.New("kubeconfig file is not a map document")
+		}
 	}
 	return nil
 }
 
+// Bytes returns the kubeconfig as a single YAML document. If multiple files
+// are loaded, their clusters, contexts and users are merged the same way
+// kubectl does: first file to define an entry wins.
 func (k *Kubeconfig) Bytes() ([]byte, error) {
-	return yaml.Marshal(k.rootNode)
+	if len(k.files) == 1 {
+		return yaml.Marshal(k.files[0].rootNode)
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(merged, "apiVersion", scalarNode("v1"))
+	setValue(merged, "kind", scalarNode("Config"))
+	for _, key := range []string{"clusters", "contexts", "users"} {
+		seq := &yaml.Node{Kind: yaml.SequenceNode}
+		seen := make(map[string]bool)
+		for _, cf := range k.files {
+			entries := valueOf(cf.rootNode, key)
+			if entries == nil || entries.Kind != yaml.SequenceNode {
+				continue
+			}
+			for _, entry := range entries.Content {
+				name := valueOf(entry, "name")
+				if name == nil || seen[name.Value] {
+					continue
+				}
+				seen[name.Value] = true
+				seq.Content = append(seq.Content, entry)
+			}
+		}
+		setValue(merged, key, seq)
+	}
+	setValue(merged, "current-context", scalarNode(k.GetCurrentContext()))
+	return yaml.Marshal(merged)
 }
 
+// Save writes the modified kubeconfig files back.
 func (k *Kubeconfig) Save() error {
-	if err := k.f.Reset(); err != nil {
-		return errors.Wrap(err, "failed to reset file")
+	for _, cf := range k.files {
+		if !cf.modified {
+			continue
+		}
+		if err := cf.f.Reset(); err != nil {
+			return errors.Wrap(err, "failed to reset file")
+		}
+		enc := yaml.NewEncoder(cf.f)
+		enc.SetIndent(0)
+		if err := enc.Encode(cf.rootNode); err != nil {
+			return err
+		}
+		cf.modified = false
+	}
+	return nil
+}
+
+// ContextSource returns the absolute path of the kubeconfig file that
+// defines the context.
+func (k *Kubeconfig) ContextSource(name string) (string, error) {
+	cf, _, err := k.contextNode(name)
+	if err != nil {
+		return "", err
+	}
+	path := cf.path()
+	if path == "" {
+		return "", errors.New("kubeconfig file path is unknown")
 	}
-	enc := yaml.NewEncoder(k.f)
-	enc.SetIndent(0)
-	return enc.Encode(k.rootNode)
+	return path, nil
+}
+
+func scalarNode(v string) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: v,
+		Tag:   "!!str"}
+}
+
+// setValue appends the key and value to the mapping node.
+func setValue(mapNode *yaml.Node, key string, value *yaml.Node) {
+	mapNode.Content = append(mapNode.Content, scalarNode(key), value)
 }
@@ -65,3 +65,73 @@ func TestSave(t *testing.T) {
 		t.Fatal(diff)
 	}
 }
+
+func TestParse_multipleFiles(t *testing.T) {
+	test := WithMockKubeconfigLoaders(
+		testutil.KC().WithCtxs(testutil.Ctx("a"), testutil.Ctx("b")).ToYAML(t),
+		testutil.KC().WithCurrentCtx("c").WithCtxs(testutil.Ctx("b"), testutil.Ctx("c")).ToYAML(t))
+	kc := new(Kubeconfig).WithLoader(test)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"a", "b", "c"}, kc.ContextNames()); diff != "" {
+		t.Fatalf("ContextNames() diff: %s", diff)
+	}
+	if v := kc.GetCurrentContext(); v != "c" {
+		t.Fatalf("GetCurrentContext()=%q; expected=%q", v, "c")
+	}
+}
+
+func TestParse_emptyFiles(t *testing.T) {
+	test := WithMockKubeconfigLoaders(
+		"",
+		testutil.KC().WithCurrentCtx("a").WithCtxs(testutil.Ctx("a")).ToYAML(t),
+		"# only a comment\n")
+	kc := new(Kubeconfig).WithLoader(test)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a"}, kc.ContextNames()); diff != "" {
+		t.Fatalf("ContextNames() diff: %s", diff)
+	}
+	if v := kc.GetCurrentContext(); v != "a" {
+		t.Fatalf("GetCurrentContext()=%q; expected=%q", v, "a")
+	}
+
+	empty := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(""))
+	defer empty.Close()
+	if err := empty.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if v := empty.ContextNames(); len(v) != 0 {
+		t.Fatalf("ContextNames()=%v; expected none", v)
+	}
+}
+
+func TestSave_multipleFilesOnlyWritesModified(t *testing.T) {
+	test := WithMockKubeconfigLoaders(
+		testutil.KC().WithCtxs(testutil.Ctx("a")).ToYAML(t),
+		testutil.KC().WithCtxs(testutil.Ctx("b")).ToYAML(t))
+	kc := new(Kubeconfig).WithLoader(test)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.SetNamespace("b", "ns"); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := test.Output(0); v != "" {
+		t.Fatalf("unmodified file was written: %s", v)
+	}
+	expected := testutil.KC().WithCtxs(testutil.Ctx("b").Ns("ns")).ToYAML(t)
+	if diff := cmp.Diff(expected, test.Output(1)); diff != "" {
+		t.Fatalf("diff: %s", diff)
+	}
+}
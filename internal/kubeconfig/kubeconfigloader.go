@@ -15,11 +15,12 @@
 package kubeconfig
 
 import (
-	"github.com/ahmetb/kubectx/internal/cmdutil"
 	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
 )
 
 var (
@@ -30,25 +31,42 @@ type StandardKubeconfigLoader struct{}
 
 type kubeconfigFile struct{ *os.File }
 
+// Load opens the kubeconfig files. Similar to kubectl, files listed in the
+// KUBECONFIG environment variable that don't exist are skipped.
 func (*StandardKubeconfigLoader) Load() ([]ReadWriteResetCloser, error) {
-	cfgPath, err := kubeconfigPath()
+	paths, err := kubeconfigPaths()
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot determine kubeconfig path")
 	}
 
-	f, err := os.OpenFile(cfgPath, os.O_RDWR, 0)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, errors.Wrap(err, "kubeconfig file not found")
+	var files []ReadWriteResetCloser
+	var notFoundErr error
+	for _, cfgPath := range paths {
+		f, err := os.OpenFile(cfgPath, os.O_RDWR, 0)
+		if err != nil {
+			if os.IsNotExist(err) {
+				if notFoundErr == nil {
+					notFoundErr = err
+				}
+				continue
+			}
+			for _, f := range files {
+				f.Close()
+			}
+			return nil, errors.Wrap(err, "failed to open file")
 		}
-		return nil, errors.Wrap(err, "failed to open file")
+		files = append(files, ReadWriteResetCloser(&kubeconfigFile{f}))
 	}
-
-	// TODO we'll return all kubeconfig files when we start implementing multiple kubeconfig support
-	return []ReadWriteResetCloser{ReadWriteResetCloser(&kubeconfigFile{f})}, nil
+	if len(files) == 0 {
+		return nil, errors.Wrap(notFoundErr, "kubeconfig file not found")
+	}
+	return files, nil
 }
 
 func (kf *kubeconfigFile) Reset() error {
+	if err := backup(kf.File); err != nil {
+		return errors.Wrap(err, "failed to back up file")
+	}
 	if err := kf.Truncate(0); err != nil {
 		return errors.Wrap(err, "failed to truncate file")
 	}
@@ -56,21 +74,35 @@ func (kf *kubeconfigFile) Reset() error {
 	return errors.Wrap(err, "failed to seek in file")
 }
 
-func kubeconfigPath() (string, error) {
+// Paths returns the paths of the kubeconfig files the DefaultLoader loads,
+// including the ones that don't exist yet.
+func Paths() ([]string, error) {
+	return kubeconfigPaths()
+}
+
+// kubeconfigPaths returns the kubeconfig file paths in the order of
+// precedence.
+func kubeconfigPaths() ([]string, error) {
 	// KUBECONFIG env var
 	if v := os.Getenv("KUBECONFIG"); v != "" {
-		list := filepath.SplitList(v)
-		if len(list) > 1 {
-			// TODO KUBECONFIG=file1:file2 currently not supported
-			return "", errors.New("multiple files in KUBECONFIG are currently not supported")
+		var paths []string
+		seen := make(map[string]bool)
+		for _, p := range filepath.SplitList(v) {
+			if p == "" || seen[p] {
+				continue
+			}
+			seen[p] = true
+			paths = append(paths, p)
+		}
+		if len(paths) > 0 {
+			return paths, nil
 		}
-		return v, nil
 	}
 
 	// default path
 	home := cmdutil.HomeDir()
 	if home == "" {
-		return "", errors.New("HOME or USERPROFILE environment variable not set")
+		return nil, errors.New("HOME or USERPROFILE environment variable not set")
 	}
-	return filepath.Join(home, ".kube", "config"), nil
+	return []string{filepath.Join(home, ".kube", "config")}, nil
 }
@@ -15,58 +15,67 @@
 package kubeconfig
 
 import (
-	"github.com/ahmetb/kubectx/internal/cmdutil"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
 	"github.com/ahmetb/kubectx/internal/testutil"
 )
 
-func Test_kubeconfigPath(t *testing.T) {
+func Test_kubeconfigPaths(t *testing.T) {
 	defer testutil.WithEnvVar("HOME", "/x/y/z")()
+	defer testutil.WithEnvVar("KUBECONFIG", "")()
 
-	expected := filepath.FromSlash("/x/y/z/.kube/config")
-	got, err := kubeconfigPath()
+	expected := []string{filepath.FromSlash("/x/y/z/.kube/config")}
+	got, err := kubeconfigPaths()
 	if err != nil {
 		t.Fatal(err)
 	}
-	if got != expected {
-		t.Fatalf("got=%q expected=%q", got, expected)
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("diff: %s", diff)
 	}
 }
 
-func Test_kubeconfigPath_noEnvVars(t *testing.T) {
+func Test_kubeconfigPaths_noEnvVars(t *testing.T) {
 	defer testutil.WithEnvVar("XDG_CACHE_HOME", "")()
 	defer testutil.WithEnvVar("HOME", "")()
 	defer testutil.WithEnvVar("USERPROFILE", "")()
 
-	_, err := kubeconfigPath()
+	defer testutil.WithEnvVar("KUBECONFIG", "")()
+
+	_, err := kubeconfigPaths()
 	if err == nil {
 		t.Fatalf("expected error")
 	}
 }
 
-func Test_kubeconfigPath_envOvveride(t *testing.T) {
+func Test_kubeconfigPaths_envOvveride(t *testing.T) {
 	defer testutil.WithEnvVar("KUBECONFIG", "foo")()
 
-	v, err := kubeconfigPath()
+	v, err := kubeconfigPaths()
 	if err != nil {
 		t.Fatal(err)
 	}
-	if expected := "foo"; v != expected {
-		t.Fatalf("expected=%q, got=%q", expected, v)
+	if diff := cmp.Diff([]string{"foo"}, v); diff != "" {
+		t.Fatalf("diff: %s", diff)
 	}
 }
 
-func Test_kubeconfigPath_envOvverideDoesNotSupportPathSeparator(t *testing.T) {
-	path := strings.Join([]string{"file1", "file2"}, string(os.PathListSeparator))
+func Test_kubeconfigPaths_envOverrideMultipleFiles(t *testing.T) {
+	path := strings.Join([]string{"file1", "", "file2", "file1"}, string(os.PathListSeparator))
 	defer testutil.WithEnvVar("KUBECONFIG", path)()
 
-	_, err := kubeconfigPath()
-	if err == nil {
-		t.Fatal("expected error")
+	v, err := kubeconfigPaths()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"file1", "file2"}, v); diff != "" {
+		t.Fatalf("diff: %s", diff)
 	}
 }
 
@@ -81,3 +90,64 @@ func TestStandardKubeconfigLoader_returnsNotFoundErr(t *testing.T) {
 		t.Fatalf("expected ENOENT error; got=%v", err)
 	}
 }
+
+func TestStandardKubeconfigLoader_skipsMissingFiles(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kubeconfig-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	existing := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(existing, []byte("current-context: foo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := strings.Join([]string{filepath.Join(dir, "missing"), existing}, string(os.PathListSeparator))
+	defer testutil.WithEnvVar("KUBECONFIG", path)()
+
+	files, err := DefaultLoader.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file; got=%d", len(files))
+	}
+}
+
+func TestKubeconfig_ContextSource(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kubeconfig-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file1, file2 := filepath.Join(dir, "config1"), filepath.Join(dir, "config2")
+	if err := ioutil.WriteFile(file1, []byte(testutil.KC().WithCtxs(testutil.Ctx("a")).ToYAML(t)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file2, []byte(testutil.KC().WithCtxs(testutil.Ctx("a"), testutil.Ctx("b")).ToYAML(t)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer testutil.WithEnvVar("KUBECONFIG", strings.Join([]string{file1, file2}, string(os.PathListSeparator)))()
+
+	kc := new(Kubeconfig).WithLoader(DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	for ctx, expected := range map[string]string{"a": file1, "b": file2} {
+		got, err := kc.ContextSource(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != expected {
+			t.Fatalf("ContextSource(%q)=%q; expected=%q", ctx, got, expected)
+		}
+	}
+	if _, err := kc.ContextSource("c"); err == nil {
+		t.Fatal("expected error for missing context")
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// pathFields are the fields of cluster and user entries holding file paths,
+// which kubectl resolves relative to the kubeconfig file defining them.
+var pathFields = map[string][]string{
+	"clusters": {"certificate-authority"},
+	"users":    {"client-certificate", "client-key", "tokenFile"},
+}
+
+// Minify returns a kubeconfig document that only has the specified context,
+// along with the cluster and user entries it refers to, and that context
+// as its current-context. Relative file paths in the entries are made
+// absolute, so the document can be written anywhere.
+func (k *Kubeconfig) Minify(ctxName string) ([]byte, error) {
+	_, ctxNode, err := k.contextNode(ctxName)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(out, "apiVersion", scalarNode("v1"))
+	setValue(out, "kind", scalarNode("Config"))
+
+	ctxBody := valueOf(ctxNode, "context")
+	for _, v := range []struct{ key, ref string }{{"clusters", "cluster"}, {"users", "user"}} {
+		seq := &yaml.Node{Kind: yaml.SequenceNode}
+		if ctxBody != nil {
+			if ref := valueOf(ctxBody, v.ref); ref != nil && ref.Value != "" {
+				entryFile, entry := k.namedEntry(v.key, ref.Value)
+				if entry == nil {
+					return nil, errors.Errorf("%s \"%s\" referenced by context \"%s\" not found", v.ref, ref.Value, ctxName)
+				}
+				entry = copyNode(entry)
+				entryFile.resolvePaths(valueOf(entry, v.ref), pathFields[v.key])
+				seq.Content = append(seq.Content, entry)
+			}
+		}
+		setValue(out, v.key, seq)
+	}
+	setValue(out, "contexts", &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{copyNode(ctxNode)}})
+	setValue(out, "current-context", scalarNode(ctxName))
+	return yaml.Marshal(out)
+}
+
+// namedEntry finds the entry with the specified name in the key (e.g.
+// "clusters") of the first file that defines it.
+func (k *Kubeconfig) namedEntry(key, name string) (*configFile, *yaml.Node) {
+	for _, cf := range k.files {
+		entries := valueOf(cf.rootNode, key)
+		if entries == nil || entries.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, entry := range entries.Content {
+			if n := valueOf(entry, "name"); n != nil && n.Value == name {
+				return cf, entry
+			}
+		}
+	}
+	return nil, nil
+}
+
+// resolvePaths makes the relative paths in the fields of the node absolute,
+// based on the directory of the file. It's a no-op if the file path is
+// unknown.
+func (cf *configFile) resolvePaths(node *yaml.Node, fields []string) {
+	f, ok := cf.f.(interface{ Name() string })
+	if node == nil || !ok {
+		return
+	}
+	dir, err := filepath.Abs(filepath.Dir(f.Name()))
+	if err != nil {
+		return
+	}
+	for _, field := range fields {
+		v := valueOf(node, field)
+		if v != nil && v.Value != "" && !filepath.IsAbs(v.Value) {
+			v.Value = filepath.Join(dir, v.Value)
+		}
+	}
+}
+
+// copyNode returns a deep copy of the node.
+func copyNode(n *yaml.Node) *yaml.Node {
+	c := *n
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, ch := range n.Content {
+		c.Content[i] = copyNode(ch)
+	}
+	return &c
+}
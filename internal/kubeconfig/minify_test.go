@@ -0,0 +1,129 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const minifyInput = `apiVersion: v1
+kind: Config
+clusters:
+- name: c1
+  cluster:
+    server: https://c1
+    certificate-authority: ca.crt
+- name: c2
+  cluster:
+    server: https://c2
+contexts:
+- name: a
+  context:
+    cluster: c1
+    user: u1
+    namespace: ns
+- name: b
+  context:
+    cluster: c2
+    user: u2
+current-context: b
+users:
+- name: u1
+  user:
+    client-key: /abs/key
+- name: u2
+  user:
+    token: t
+`
+
+// namedMockLoader is a mock kubeconfig file with a path.
+type namedMockLoader struct {
+	*MockKubeconfigLoader
+	name string
+}
+
+func (t *namedMockLoader) Name() string { return t.name }
+func (t *namedMockLoader) Load() ([]ReadWriteResetCloser, error) {
+	return []ReadWriteResetCloser{t}, nil
+}
+
+func TestKubeconfig_Minify(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(&namedMockLoader{
+		MockKubeconfigLoader: WithMockKubeconfigLoader(minifyInput),
+		name:                 filepath.FromSlash("/home/me/.kube/config")})
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	before, err := kc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := kc.Minify("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `apiVersion: v1
+kind: Config
+clusters:
+    - name: c1
+      cluster:
+        server: https://c1
+        certificate-authority: ` + filepath.FromSlash("/home/me/.kube/ca.crt") + `
+users:
+    - name: u1
+      user:
+        client-key: /abs/key
+contexts:
+    - name: a
+      context:
+        cluster: c1
+        user: u1
+        namespace: ns
+current-context: a
+`
+	if diff := cmp.Diff(expected, string(out)); diff != "" {
+		t.Fatalf("diff: %s", diff)
+	}
+
+	after, err := kc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(before), string(after)); diff != "" {
+		t.Fatalf("Minify() modified the kubeconfig: %s", diff)
+	}
+}
+
+func TestKubeconfig_Minify_errors(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(`contexts:
+- name: a
+  context:
+    cluster: missing`))
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kc.Minify("a"); err == nil {
+		t.Fatal("expected error for missing cluster")
+	}
+	if _, err := kc.Minify("nonexistent"); err == nil {
+		t.Fatal("expected error for missing context")
+	}
+}
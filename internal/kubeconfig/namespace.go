@@ -21,7 +21,7 @@ const (
 )
 
 func (k *Kubeconfig) NamespaceOfContext(contextName string) (string, error) {
-	ctx, err := k.contextNode(contextName)
+	_, ctx, err := k.contextNode(contextName)
 	if err != nil {
 		return "", err
 	}
@@ -36,11 +36,15 @@ func (k *Kubeconfig) NamespaceOfContext(contextName string) (string, error) {
 	return ns.Value, nil
 }
 
+// SetNamespace changes the namespace of the context. The namespace is always
+// written explicitly, including "default", so tools treating a missing
+// namespace differently all see the same one.
 func (k *Kubeconfig) SetNamespace(ctxName string, ns string) error {
-	ctxNode, err := k.contextNode(ctxName)
+	cf, ctxNode, err := k.contextNode(ctxName)
 	if err != nil {
 		return err
 	}
+	cf.modified = true
 
 	var ctxBodyNodeWasEmpty bool // actual namespace value is in contexts[index].context.namespace, but .context might not exist
 	ctxBodyNode := valueOf(ctxNode, "context")
@@ -57,21 +61,9 @@ func (k *Kubeconfig) SetNamespace(ctxName string, ns string) error {
 		return nil
 	}
 
-	keyNode := &yaml.Node{
-		Kind:  yaml.ScalarNode,
-		Value: "namespace",
-		Tag:   "!!str"}
-	valueNode := &yaml.Node{
-		Kind:  yaml.ScalarNode,
-		Value: ns,
-		Tag:   "!!str"}
-	ctxBodyNode.Content = append(ctxBodyNode.Content, keyNode, valueNode)
+	setValue(ctxBodyNode, "namespace", scalarNode(ns))
 	if ctxBodyNodeWasEmpty {
-		ctxNode.Content = append(ctxNode.Content, &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Value: "context",
-			Tag:   "!!str",
-		}, ctxBodyNode)
+		setValue(ctxNode, "context", ctxBodyNode)
 	}
 	return nil
 }
@@ -92,3 +92,29 @@ func TestKubeconfig_SetNamespace(t *testing.T) {
 		t.Fatal(diff)
 	}
 }
+
+func TestKubeconfig_SetNamespace_writesDefault(t *testing.T) {
+	l := WithMockKubeconfigLoader(testutil.KC().
+		WithCtxs(
+			testutil.Ctx("c1"),
+			testutil.Ctx("c2").Ns("c2n1")).ToYAML(t))
+	kc := new(Kubeconfig).WithLoader(l)
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	for _, ctx := range []string{"c1", "c2"} {
+		if err := kc.SetNamespace(ctx, "default"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := kc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := testutil.KC().WithCtxs(
+		testutil.Ctx("c1").Ns("default"),
+		testutil.Ctx("c2").Ns("default")).ToYAML(t)
+	if diff := cmp.Diff(l.Output(), expected); diff != "" {
+		t.Fatal(diff)
+	}
+}
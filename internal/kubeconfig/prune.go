@@ -0,0 +1,83 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// Orphans returns the names of the users and clusters that no context in the
+// kubeconfig files refers to, in the order they're defined.
+func (k *Kubeconfig) Orphans() (users, clusters []string) {
+	refs := map[string]map[string]bool{"user": {}, "cluster": {}}
+	for _, cf := range k.files {
+		contexts, err := cf.contextsNode()
+		if err != nil || contexts == nil {
+			continue
+		}
+		for _, ctx := range contexts.Content {
+			body := valueOf(ctx, "context")
+			if body == nil {
+				continue
+			}
+			for field, names := range refs {
+				if v := valueOf(body, field); v != nil && v.Value != "" {
+					names[v.Value] = true
+				}
+			}
+		}
+	}
+	for _, n := range k.UserNames() {
+		if !refs["user"][n] {
+			users = append(users, n)
+		}
+	}
+	for _, n := range k.ClusterNames() {
+		if !refs["cluster"][n] {
+			clusters = append(clusters, n)
+		}
+	}
+	return users, clusters
+}
+
+// DeleteUserEntry removes the user with the specified name from every file
+// that defines it.
+func (k *Kubeconfig) DeleteUserEntry(name string) {
+	k.deleteNamedEntries("users", name)
+}
+
+// DeleteClusterEntry removes the cluster with the specified name from every
+// file that defines it.
+func (k *Kubeconfig) DeleteClusterEntry(name string) {
+	k.deleteNamedEntries("clusters", name)
+}
+
+func (k *Kubeconfig) deleteNamedEntries(key, name string) {
+	for _, cf := range k.files {
+		entries := valueOf(cf.rootNode, key)
+		if entries == nil || entries.Kind != yaml.SequenceNode {
+			continue
+		}
+		var kept []*yaml.Node
+		for _, entry := range entries.Content {
+			if n := valueOf(entry, "name"); n != nil && n.Value == name {
+				cf.modified = true
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		entries.Content = kept
+	}
+}
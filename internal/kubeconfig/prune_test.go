@@ -0,0 +1,91 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const pruneTestConfig = `contexts:
+- name: c1
+  context:
+    cluster: cluster1
+    user: user1
+- name: c2
+  context:
+    cluster: cluster1
+clusters:
+- name: cluster1
+- name: cluster2
+users:
+- name: user1
+- name: user2
+- name: user3
+`
+
+func TestKubeconfig_Orphans(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoader(pruneTestConfig))
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	users, clusters := kc.Orphans()
+	if diff := cmp.Diff([]string{"user2", "user3"}, users); diff != "" {
+		t.Fatalf("users diff=%s", diff)
+	}
+	if diff := cmp.Diff([]string{"cluster2"}, clusters); diff != "" {
+		t.Fatalf("clusters diff=%s", diff)
+	}
+}
+
+func TestKubeconfig_Orphans_referencedFromOtherFile(t *testing.T) {
+	kc := new(Kubeconfig).WithLoader(WithMockKubeconfigLoaders(
+		"clusters:\n- name: cluster1\nusers:\n- name: user1\n",
+		"contexts:\n- name: c1\n  context:\n    cluster: cluster1\n    user: user1\n"))
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if users, clusters := kc.Orphans(); users != nil || clusters != nil {
+		t.Fatalf("expected no orphans; got users=%v clusters=%v", users, clusters)
+	}
+}
+
+func TestKubeconfig_DeleteUserAndClusterEntry(t *testing.T) {
+	test := WithMockKubeconfigLoader(pruneTestConfig)
+	kc := new(Kubeconfig).WithLoader(test)
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	kc.DeleteUserEntry("user2")
+	kc.DeleteClusterEntry("cluster2")
+	kc.DeleteClusterEntry("missing")
+	if err := kc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	out := test.Output()
+	for _, removed := range []string{"user2", "cluster2"} {
+		if strings.Contains(out, removed) {
+			t.Errorf("%q not removed from output:\n%s", removed, out)
+		}
+	}
+	for _, kept := range []string{"user1", "user3", "cluster1"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("%q missing from output:\n%s", kept, out)
+		}
+	}
+}
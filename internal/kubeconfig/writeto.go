@@ -0,0 +1,73 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// writeTo is the absolute path of the file to write current-context and new
+// entries to, or "" to pick the file like kubectl does.
+var writeTo string
+
+// SetWriteTo makes current-context and new entries be written to the
+// kubeconfig file at path, which must be one of the files in KUBECONFIG.
+// Existing entries are still modified in the file that defines them.
+func SetWriteTo(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to determine absolute path")
+	}
+	paths, err := kubeconfigPaths()
+	if err != nil {
+		return errors.Wrap(err, "cannot determine kubeconfig path")
+	}
+	for _, p := range paths {
+		if v, err := filepath.Abs(p); err == nil && v == abs {
+			writeTo = abs
+			return nil
+		}
+	}
+	return errors.Errorf("\"%s\" is not one of the kubeconfig files (%s)", path, strings.Join(paths, ", "))
+}
+
+// path returns the absolute path of the file, or "" if it's unknown.
+func (cf *configFile) path() string {
+	f, ok := cf.f.(interface{ Name() string })
+	if !ok {
+		return ""
+	}
+	v, err := filepath.Abs(f.Name())
+	if err != nil {
+		return ""
+	}
+	return v
+}
+
+// writeTarget returns the file set with SetWriteTo, or def if there is none.
+func (k *Kubeconfig) writeTarget(def *configFile) (*configFile, error) {
+	if writeTo == "" {
+		return def, nil
+	}
+	for _, cf := range k.files {
+		if cf.path() == writeTo {
+			return cf, nil
+		}
+	}
+	return nil, errors.Errorf("kubeconfig file \"%s\" to write to doesn't exist", writeTo)
+}
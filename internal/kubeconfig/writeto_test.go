@@ -0,0 +1,108 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+// withKubeconfigFiles writes the kubeconfig files to a temporary directory
+// and sets KUBECONFIG to them.
+func withKubeconfigFiles(t *testing.T, kubecfgs ...string) (paths []string, cleanup func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir(os.TempDir(), "writeto-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range kubecfgs {
+		p := filepath.Join(dir, string(rune('a'+i)))
+		if err := ioutil.WriteFile(p, []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, p)
+	}
+	unset := testutil.WithEnvVar("KUBECONFIG", strings.Join(paths, string(os.PathListSeparator)))
+	return paths, func() {
+		unset()
+		writeTo = ""
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSetWriteTo_notInKubeconfig(t *testing.T) {
+	_, cleanup := withKubeconfigFiles(t, testutil.KC().ToYAML(t))
+	defer cleanup()
+
+	if err := SetWriteTo("other"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestKubeconfig_ModifyCurrentContext_writeTo(t *testing.T) {
+	paths, cleanup := withKubeconfigFiles(t,
+		testutil.KC().WithCtxs(testutil.Ctx("a")).ToYAML(t),
+		testutil.KC().WithCtxs(testutil.Ctx("b")).ToYAML(t))
+	defer cleanup()
+
+	if err := SetWriteTo(paths[1]); err != nil {
+		t.Fatal(err)
+	}
+	kc := new(Kubeconfig).WithLoader(DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.ModifyCurrentContext("b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []bool{false, true} {
+		b, err := ioutil.ReadFile(paths[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(string(b), "current-context: b"); got != want {
+			t.Errorf("file %d has current-context=%v, want=%v:\n%s", i, got, want, b)
+		}
+	}
+}
+
+func TestKubeconfig_ModifyCurrentContext_writeToOverridden(t *testing.T) {
+	paths, cleanup := withKubeconfigFiles(t,
+		testutil.KC().WithCurrentCtx("a").WithCtxs(testutil.Ctx("a")).ToYAML(t),
+		testutil.KC().WithCtxs(testutil.Ctx("b")).ToYAML(t))
+	defer cleanup()
+
+	if err := SetWriteTo(paths[1]); err != nil {
+		t.Fatal(err)
+	}
+	kc := new(Kubeconfig).WithLoader(DefaultLoader)
+	defer kc.Close()
+	if err := kc.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if err := kc.ModifyCurrentContext("b"); err == nil {
+		t.Fatal("expected error as the first file sets current-context")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"facette.io/natsort"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+var defaultBookmarksDir = filepath.Join(cmdutil.HomeDir(), ".kube", "kubens-bookmarks")
+
+// BookmarkFile stores the bookmarked namespaces of a context, one per line,
+// sorted by name.
+type BookmarkFile struct {
+	dir string
+	ctx string
+}
+
+func NewBookmarkFile(ctx string) BookmarkFile {
+	return BookmarkFile{dir: defaultBookmarksDir, ctx: ctx}
+}
+
+func (f BookmarkFile) path() string {
+	return filepath.Join(f.dir, contextFileName(f.ctx))
+}
+
+// Load reads the bookmarked namespaces, or returns empty if not exists.
+func (f BookmarkFile) Load() ([]string, error) {
+	b, err := ioutil.ReadFile(f.path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []string
+	for _, l := range strings.Split(string(b), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// Add bookmarks the namespace. It returns false if it was already
+// bookmarked.
+func (f BookmarkFile) Add(ns string) (bool, error) {
+	bookmarks, err := f.Load()
+	if err != nil {
+		return false, err
+	}
+	for _, v := range bookmarks {
+		if v == ns {
+			return false, nil
+		}
+	}
+	bookmarks = append(bookmarks, ns)
+	natsort.Sort(bookmarks)
+	return true, f.save(bookmarks)
+}
+
+// Remove removes the bookmark of the namespace. It returns false if it
+// wasn't bookmarked.
+func (f BookmarkFile) Remove(ns string) (bool, error) {
+	bookmarks, err := f.Load()
+	if err != nil {
+		return false, err
+	}
+	var out []string
+	for _, v := range bookmarks {
+		if v != ns {
+			out = append(out, v)
+		}
+	}
+	if len(out) == len(bookmarks) {
+		return false, nil
+	}
+	return true, f.save(out)
+}
+
+func (f BookmarkFile) save(bookmarks []string) error {
+	var b strings.Builder
+	for _, v := range bookmarks {
+		b.WriteString(v + "\n")
+	}
+	if err := os.MkdirAll(f.dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(f.path(), []byte(b.String()), 0644)
+}
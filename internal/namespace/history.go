@@ -0,0 +1,155 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+// maxHistory is the number of namespaces remembered for each context.
+const maxHistory = 50
+
+var defaultHistoryDir = filepath.Join(cmdutil.HomeDir(), ".kube", "kubens-history")
+
+// HistoryFile stores the namespaces recently used in a context, most
+// recent first, one per line. Each line can be followed by a tab and the
+// number of times the namespace was switched to.
+type HistoryFile struct {
+	dir string
+	ctx string
+}
+
+// HistoryEntry is a namespace in the history, and the number of times it was
+// switched to.
+type HistoryEntry struct {
+	Namespace string
+	Switches  int
+}
+
+func NewHistoryFile(ctx string) HistoryFile { return HistoryFile{dir: defaultHistoryDir, ctx: ctx} }
+
+func (f HistoryFile) path() string {
+	return filepath.Join(f.dir, contextFileName(f.ctx))
+}
+
+// Load reads the recently used namespaces, or returns empty if not exists.
+func (f HistoryFile) Load() ([]string, error) {
+	entries, err := f.entries()
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.Namespace)
+	}
+	return out, nil
+}
+
+// Stats returns the namespaces switched to at least once, most switched
+// first.
+func (f HistoryFile) Stats() ([]HistoryEntry, error) {
+	entries, err := f.entries()
+	if err != nil {
+		return nil, err
+	}
+	out := []HistoryEntry{}
+	for _, e := range entries {
+		if e.Switches > 0 {
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Switches != out[j].Switches {
+			return out[i].Switches > out[j].Switches
+		}
+		return out[i].Namespace < out[j].Namespace
+	})
+	return out, nil
+}
+
+// ResetStats clears the switch counts, keeping the recently used namespaces.
+func (f HistoryFile) ResetStats() error {
+	entries, err := f.entries()
+	if err != nil || entries == nil {
+		return err
+	}
+	for i := range entries {
+		entries[i].Switches = 0
+	}
+	return f.write(entries)
+}
+
+// Touch marks the namespace as the most recently used one, and counts the
+// switch to it.
+func (f HistoryFile) Touch(ns string) error {
+	prev, err := f.entries()
+	if err != nil {
+		return err
+	}
+	out := []HistoryEntry{{Namespace: ns, Switches: 1}}
+	for _, e := range prev {
+		if e.Namespace == ns {
+			out[0].Switches += e.Switches
+		} else if len(out) < maxHistory {
+			out = append(out, e)
+		}
+	}
+	return f.write(out)
+}
+
+func (f HistoryFile) entries() ([]HistoryEntry, error) {
+	b, err := ioutil.ReadFile(f.path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []HistoryEntry
+	for _, l := range strings.Split(string(b), "\n") {
+		if l = strings.TrimSpace(l); l == "" {
+			continue
+		}
+		e := HistoryEntry{Namespace: l}
+		if i := strings.IndexByte(l, '\t'); i >= 0 {
+			e.Namespace = l[:i]
+			e.Switches, _ = strconv.Atoi(l[i+1:]) // counts are best effort
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func (f HistoryFile) write(entries []HistoryEntry) error {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.Namespace)
+		if e.Switches > 0 {
+			b.WriteString("\t" + strconv.Itoa(e.Switches))
+		}
+		b.WriteString("\n")
+	}
+	if err := os.MkdirAll(f.dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(f.path(), []byte(b.String()), 0644)
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+var defaultPinFile = filepath.Join(cmdutil.HomeDir(), ".kube", "kubens-pins")
+
+// PinFile stores the names of the contexts whose namespace is pinned,
+// one per line. A pinned namespace should not be changed when switching
+// contexts.
+type PinFile struct {
+	path string
+}
+
+func NewPinFile() PinFile { return PinFile{path: defaultPinFile} }
+
+// Load reads the pinned context names, or returns empty if not exists.
+func (f PinFile) Load() ([]string, error) {
+	b, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []string
+	for _, l := range strings.Split(string(b), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// IsPinned determines if the namespace of the context is pinned.
+func (f PinFile) IsPinned(ctx string) (bool, error) {
+	pins, err := f.Load()
+	if err != nil {
+		return false, err
+	}
+	for _, v := range pins {
+		if v == ctx {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Pin marks the namespace of the context as pinned.
+func (f PinFile) Pin(ctx string) error {
+	pins, err := f.Load()
+	if err != nil {
+		return err
+	}
+	for _, v := range pins {
+		if v == ctx {
+			return nil
+		}
+	}
+	return f.save(append(pins, ctx))
+}
+
+// Unpin removes the pin from the namespace of the context.
+func (f PinFile) Unpin(ctx string) error {
+	pins, err := f.Load()
+	if err != nil {
+		return err
+	}
+	var out []string
+	for _, v := range pins {
+		if v != ctx {
+			out = append(out, v)
+		}
+	}
+	return f.save(out)
+}
+
+func (f PinFile) save(pins []string) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, v := range pins {
+		b.WriteString(v + "\n")
+	}
+	return ioutil.WriteFile(f.path, []byte(b.String()), 0644)
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPinFile(t *testing.T) {
+	td, err := ioutil.TempDir(os.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	f := PinFile{path: filepath.Join(td, "pins")}
+	if ok, err := f.IsPinned("a"); err != nil || ok {
+		t.Fatalf("IsPinned() on missing file = %v, err=%v", ok, err)
+	}
+
+	for _, ctx := range []string{"a", "b", "a"} {
+		if err := f.Pin(ctx); err != nil {
+			t.Fatalf("Pin(%q) err=%v", ctx, err)
+		}
+	}
+	v, err := f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, v); diff != "" {
+		t.Fatalf("Load() diff=%s", diff)
+	}
+
+	if err := f.Unpin("a"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := f.IsPinned("a"); err != nil || ok {
+		t.Fatalf("IsPinned(a) after Unpin = %v, err=%v", ok, err)
+	}
+	if ok, err := f.IsPinned("b"); err != nil || !ok {
+		t.Fatalf("IsPinned(b) = %v, err=%v", ok, err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmetb/kubectx/internal/cmdutil"
+)
+
+var defaultSessionDir = filepath.Join(cmdutil.HomeDir(), ".kube", "kubens-sessions")
+
+// SessionFile stores the namespace last used in a context in a shell
+// session.
+type SessionFile struct {
+	dir     string
+	session string
+	ctx     string
+}
+
+func NewSessionFile(session, ctx string) SessionFile {
+	return SessionFile{dir: defaultSessionDir, session: session, ctx: ctx}
+}
+
+// nsFile returns the file of the context in the state directory of the
+// session, which has the same format as the previous namespace files.
+func (f SessionFile) nsFile() NSFile {
+	return NSFile{dir: filepath.Join(f.dir, sessionDirName(f.session)), ctx: f.ctx}
+}
+
+// sessionDirName returns the name of the state directory of a session, as
+// session identifiers can contain characters like '/' and ':'.
+func sessionDirName(session string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, session)
+}
+
+// Load reads the namespace last used in the session, or returns empty if not
+// exists.
+func (f SessionFile) Load() (string, error) {
+	return f.nsFile().Load()
+}
+
+// Save stores the namespace used in the session.
+func (f SessionFile) Save(ns string) error {
+	return f.nsFile().Save(ns)
+}
+
+// SessionNamespace returns the namespace last used in the context in the
+// current shell session, or "" if there's none, session namespaces aren't
+// enabled, or the namespace of the context is pinned.
+func SessionNamespace(ctx string) (string, error) {
+	session := cmdutil.SessionID()
+	if session == "" {
+		return "", nil
+	}
+	if pinned, err := NewPinFile().IsPinned(ctx); err != nil || pinned {
+		return "", err
+	}
+	return NewSessionFile(session, ctx).Load()
+}
+
+// saveSessionNamespace remembers ns as the namespace last used in the
+// context in the current shell session, if session namespaces are enabled.
+func saveSessionNamespace(ctx, ns string) error {
+	session := cmdutil.SessionID()
+	if session == "" {
+		return nil
+	}
+	return NewSessionFile(session, ctx).Save(ns)
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ahmetb/kubectx/internal/env"
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func Test_sessionDirName(t *testing.T) {
+	if got, want := sessionDirName("w0t0p0:AB/12"), "w0t0p0_AB_12"; got != want {
+		t.Fatalf("sessionDirName()=%q; want=%q", got, want)
+	}
+}
+
+func TestSessionNamespace(t *testing.T) {
+	td := t.TempDir()
+	defer func(dir, pins string) { defaultSessionDir, defaultPinFile = dir, pins }(defaultSessionDir, defaultPinFile)
+	defaultSessionDir, defaultPinFile = td, filepath.Join(td, "pins")
+	defer testutil.WithEnvVar(env.EnvSessionID, "s1")()
+
+	// not enabled
+	defer testutil.WithEnvVar(env.EnvSessionNamespaces, "")()
+	if err := saveSessionNamespace("ctx", "ns1"); err != nil {
+		t.Fatal(err)
+	}
+	if ns, err := NewSessionFile("s1", "ctx").Load(); err != nil || ns != "" {
+		t.Fatalf("saved %q without session namespaces enabled, err=%v", ns, err)
+	}
+
+	defer testutil.WithEnvVar(env.EnvSessionNamespaces, "1")()
+	if err := saveSessionNamespace("ctx", "ns1"); err != nil {
+		t.Fatal(err)
+	}
+	if ns, err := SessionNamespace("ctx"); err != nil || ns != "ns1" {
+		t.Fatalf("SessionNamespace()=%q, err=%v; want=ns1", ns, err)
+	}
+	if ns, err := SessionNamespace("other"); err != nil || ns != "" {
+		t.Fatalf("SessionNamespace(other)=%q, err=%v; want empty", ns, err)
+	}
+
+	// other session
+	defer testutil.WithEnvVar(env.EnvSessionID, "s2")()
+	if ns, err := SessionNamespace("ctx"); err != nil || ns != "" {
+		t.Fatalf("SessionNamespace() in another session=%q, err=%v; want empty", ns, err)
+	}
+
+	// pinned
+	defer testutil.WithEnvVar(env.EnvSessionID, "s1")()
+	if err := NewPinFile().Pin("ctx"); err != nil {
+		t.Fatal(err)
+	}
+	if ns, err := SessionNamespace("ctx"); err != nil || ns != "" {
+		t.Fatalf("SessionNamespace() of pinned context=%q, err=%v; want empty", ns, err)
+	}
+}
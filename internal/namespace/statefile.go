@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package namespace
 
 import (
 	"bytes"
@@ -27,6 +27,7 @@ import (
 
 var defaultDir = filepath.Join(cmdutil.HomeDir(), ".kube", "kubens")
 
+// NSFile stores the previous namespace of a context.
 type NSFile struct {
 	dir string
 	ctx string
@@ -35,12 +36,16 @@ type NSFile struct {
 func NewNSFile(ctx string) NSFile { return NSFile{dir: defaultDir, ctx: ctx} }
 
 func (f NSFile) path() string {
-	fn := f.ctx
+	return filepath.Join(f.dir, contextFileName(f.ctx))
+}
+
+// contextFileName returns the name of a per-context state file.
+func contextFileName(ctx string) string {
 	if isWindows() {
 		// bug 230: eks clusters contain ':' in ctx name, not a valid file name for win32
-		fn = strings.ReplaceAll(fn, ":", "__")
+		return strings.ReplaceAll(ctx, ":", "__")
 	}
-	return filepath.Join(f.dir, fn)
+	return ctx
 }
 
 // Load reads the previous namespace setting, or returns empty if not exists.
@@ -0,0 +1,198 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ahmetb/kubectx/internal/testutil"
+)
+
+func TestNSFile(t *testing.T) {
+	td, err := ioutil.TempDir(os.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	f := NewNSFile("foo")
+	f.dir = td
+	v, err := f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "" {
+		t.Fatalf("Load() expected empty; got=%v", err)
+	}
+
+	err = f.Save("bar")
+	if err != nil {
+		t.Fatalf("Save() err=%v", err)
+	}
+
+	v, err = f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "bar"; v != expected {
+		t.Fatalf("Load()=\"%s\"; expected=\"%s\"", v, expected)
+	}
+}
+
+func TestNSFile_path_windows(t *testing.T) {
+	defer testutil.WithEnvVar("_FORCE_GOOS", "windows")()
+	fp := NewNSFile("a:b:c").path()
+
+	if expected := "a__b__c"; !strings.HasSuffix(fp, expected) {
+		t.Fatalf("file did not have expected ending %q: %s", expected, fp)
+	}
+}
+
+func Test_isWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("won't test this case on windows")
+	}
+
+	got := isWindows()
+	if got {
+		t.Fatalf("isWindows() returned true for %s", runtime.GOOS)
+	}
+
+	defer testutil.WithEnvVar("_FORCE_GOOS", "windows")()
+	if !isWindows() {
+		t.Fatalf("isWindows() failed to detect windows with env override.")
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	td, err := ioutil.TempDir(os.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	f := NewHistoryFile("foo")
+	f.dir = td
+	v, err := f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil {
+		t.Fatalf("Load() expected empty; got=%v", v)
+	}
+
+	for _, ns := range []string{"a", "b", "a", "c"} {
+		if err := f.Touch(ns); err != nil {
+			t.Fatalf("Touch(%q) err=%v", ns, err)
+		}
+	}
+	v, err = f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "c,a,b"; strings.Join(v, ",") != expected {
+		t.Fatalf("Load()=%v; expected=%s", v, expected)
+	}
+}
+
+func TestHistoryFile_Stats(t *testing.T) {
+	td, err := ioutil.TempDir(os.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	f := NewHistoryFile("foo")
+	f.dir = td
+	for _, ns := range []string{"a", "b", "a", "c", "b", "a"} {
+		if err := f.Touch(ns); err != nil {
+			t.Fatalf("Touch(%q) err=%v", ns, err)
+		}
+	}
+	v, err := f.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []HistoryEntry{{"a", 3}, {"b", 2}, {"c", 1}}
+	if diff := cmp.Diff(want, v); diff != "" {
+		t.Fatalf("Stats() diff=%s", diff)
+	}
+
+	if err := f.ResetStats(); err != nil {
+		t.Fatal(err)
+	}
+	if v, err = f.Stats(); err != nil {
+		t.Fatal(err)
+	} else if len(v) != 0 {
+		t.Fatalf("expected no stats after reset; got=%v", v)
+	}
+	ns, err := f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "a,b,c"; strings.Join(ns, ",") != expected {
+		t.Fatalf("Load()=%v; expected=%s", ns, expected)
+	}
+}
+
+func TestBookmarkFile(t *testing.T) {
+	td, err := ioutil.TempDir(os.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	f := NewBookmarkFile("foo")
+	f.dir = td
+	if v, err := f.Load(); err != nil {
+		t.Fatal(err)
+	} else if v != nil {
+		t.Fatalf("Load() expected empty; got=%v", v)
+	}
+
+	for _, ns := range []string{"ns10", "ns2", "ns2"} {
+		if _, err := f.Add(ns); err != nil {
+			t.Fatalf("Add(%q) err=%v", ns, err)
+		}
+	}
+	if added, err := f.Add("ns2"); err != nil || added {
+		t.Fatalf("Add() of existing bookmark: added=%v err=%v", added, err)
+	}
+	v, err := f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"ns2", "ns10"}, v); diff != "" {
+		t.Fatalf("Load() diff=%s", diff)
+	}
+
+	if removed, err := f.Remove("ns2"); err != nil || !removed {
+		t.Fatalf("Remove(): removed=%v err=%v", removed, err)
+	}
+	if removed, err := f.Remove("missing"); err != nil || removed {
+		t.Fatalf("Remove() of missing bookmark: removed=%v err=%v", removed, err)
+	}
+	if v, err = f.Load(); err != nil {
+		t.Fatal(err)
+	} else if diff := cmp.Diff([]string{"ns10"}, v); diff != "" {
+		t.Fatalf("Load() diff=%s", diff)
+	}
+}
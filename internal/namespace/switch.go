@@ -0,0 +1,119 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ahmetb/kubectx/internal/kubeclient"
+	"github.com/ahmetb/kubectx/internal/kubeconfig"
+)
+
+// NotFoundError indicates the namespace to switch to doesn't exist.
+type NotFoundError struct {
+	Namespace string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("no namespace exists with name \"%s\"", e.Namespace)
+}
+
+// Switch changes the namespace of the current context to ns, or to the
+// previous namespace if ns is "-". Unless forced, ns must exist in the
+// cluster. It returns the namespace switched to.
+func Switch(kc *kubeconfig.Kubeconfig, ns string, force bool) (string, error) {
+	ctx := kc.GetCurrentContext()
+	if ctx == "" {
+		return "", errors.New("current-context is not set")
+	}
+	return SwitchContext(kc, ctx, ns, force)
+}
+
+// SwitchContext is like Switch, but changes the namespace of the specified
+// context, without changing the current-context.
+func SwitchContext(kc *kubeconfig.Kubeconfig, ctx, ns string, force bool) (string, error) {
+	curNS, err := kc.NamespaceOfContext(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get current namespace")
+	}
+
+	f := NewNSFile(ctx)
+	prev, err := f.Load()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load previous namespace from file")
+	}
+
+	if ns == "-" {
+		if prev == "" {
+			return "", errors.Errorf("No previous namespace found for current context (%s)", ctx)
+		}
+		ns = prev
+	}
+
+	if !force {
+		ok, err := Exists(kc, ctx, ns)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to query if namespace exists (is cluster accessible?)")
+		}
+		if !ok {
+			return "", NotFoundError{Namespace: ns}
+		}
+	}
+
+	if err := kc.SetNamespace(ctx, ns); err != nil {
+		return "", errors.Wrapf(err, "failed to change to namespace \"%s\"", ns)
+	}
+	if err := kc.Save(); err != nil {
+		return "", errors.Wrap(err, "failed to save kubeconfig file")
+	}
+	if curNS != ns {
+		if err := f.Save(curNS); err != nil {
+			return "", errors.Wrap(err, "failed to save the previous namespace to file")
+		}
+	}
+	if err := NewHistoryFile(ctx).Touch(ns); err != nil {
+		return "", errors.Wrap(err, "failed to save namespace history")
+	}
+	if err := saveSessionNamespace(ctx, ns); err != nil {
+		return "", errors.Wrap(err, "failed to save the namespace of this session")
+	}
+	return ns, nil
+}
+
+// Exists determines if the namespace exists in the cluster of the context.
+func Exists(kc *kubeconfig.Kubeconfig, ctx, ns string) (bool, error) {
+	// for tests
+	if os.Getenv("_MOCK_NAMESPACES") != "" {
+		return ns == "ns1" || ns == "ns2", nil
+	}
+
+	clientset, err := kubeclient.NewClientSet(kc, ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to initialize k8s REST client")
+	}
+
+	v, err := clientset.CoreV1().Namespaces().Get(context.Background(), ns, metav1.GetOptions{})
+	if errors2.IsNotFound(err) {
+		return false, nil
+	}
+	return v != nil, errors.Wrapf(err, "failed to query "+
+		"namespace %q from k8s API", ns)
+}
@@ -25,6 +25,10 @@ var (
 	ErrorColor   = color.New(color.FgRed, color.Bold)
 	WarningColor = color.New(color.FgYellow, color.Bold)
 	SuccessColor = color.New(color.FgGreen)
+
+	// RemovedColor is used for names that are going away, like the old
+	// name of a renamed context.
+	RemovedColor = color.New(color.FgRed, color.CrossedOut)
 )
 
 func init() {
@@ -36,13 +40,21 @@ func init() {
 		ErrorColor.EnableColor()
 		WarningColor.EnableColor()
 		SuccessColor.EnableColor()
+		RemovedColor.EnableColor()
 	} else {
-		ErrorColor.DisableColor()
-		WarningColor.DisableColor()
-		SuccessColor.DisableColor()
+		DisableColors()
 	}
 }
 
+// DisableColors turns off colored output regardless of the environment.
+func DisableColors() {
+	ErrorColor.DisableColor()
+	WarningColor.DisableColor()
+	SuccessColor.DisableColor()
+	RemovedColor.DisableColor()
+	ActiveItemColor.DisableColor()
+}
+
 func Error(w io.Writer, format string, args ...interface{}) error {
 	_, err := fmt.Fprintf(w, ErrorColor.Sprint("error: ")+format+"\n", args...)
 	return err
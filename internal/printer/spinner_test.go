@@ -0,0 +1,61 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+func TestStartSpinner_stoppedBeforeDelay(t *testing.T) {
+	var buf syncBuffer
+	stop := StartSpinner(&buf, "fetching", time.Hour, time.Millisecond)
+	stop()
+	if v := buf.String(); v != "" {
+		t.Fatalf("expected no output; got=%q", v)
+	}
+}
+
+func TestStartSpinner_clearsLine(t *testing.T) {
+	var buf syncBuffer
+	stop := StartSpinner(&buf, "fetching", 0, time.Millisecond)
+	for !strings.Contains(buf.String(), "fetching") {
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	if v := buf.String(); !strings.HasSuffix(v, "\r\033[K") {
+		t.Fatalf("expected output to end with a cleared line; got=%q", v)
+	}
+}
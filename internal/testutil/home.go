@@ -0,0 +1,45 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TempHome points HOME to a new temporary directory for the duration of the
+// test, unsetting XDG_STATE_HOME and XDG_CACHE_HOME so the state files are
+// kept in it too, and returns the directory.
+func TempHome(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for k, v := range map[string]string{"HOME": dir, "XDG_STATE_HOME": "", "XDG_CACHE_HOME": ""} {
+		t.Cleanup(WithEnvVar(k, v))
+	}
+	return dir
+}
+
+// TempKubeconfig writes the kubeconfig to a "config" file in dir and points
+// KUBECONFIG to it for the duration of the test, returning the file path.
+func TempKubeconfig(t *testing.T, dir string, kc *Kubeconfig) string {
+	t.Helper()
+	path := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(path, []byte(kc.ToYAML(t)), 0644); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	t.Cleanup(WithEnvVar("KUBECONFIG", path))
+	return path
+}
@@ -25,6 +25,21 @@ load common
   [[ $output = *"no previous context found" ]]
 }
 
+@test "switch to context in KUBECTX_PREVIOUS" {
+  use_config config2
+  switch_context user1@cluster1
+
+  KUBECTX_PREVIOUS=user2@cluster1 run ${COMMAND} -
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$(get_context)" = "user2@cluster1" ]]
+
+  run ${COMMAND} -
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$(get_context)" = "user1@cluster1" ]]
+}
+
 @test "list contexts when no kubeconfig exists" {
   run ${COMMAND}
   echo "$output"
@@ -114,6 +129,15 @@ load common
   [ $status -eq 1 ]
 }
 
+@test "--plain-current prints nothing when no context set" {
+  use_config config1
+
+  run "${COMMAND}" --plain-current
+  echo "$output"
+  [ $status -eq 0 ]
+  [[ "$output" = "" ]]
+}
+
 @test "-c/--current prints the current context" {
   use_config config1
 
@@ -242,3 +266,190 @@ load common
   run ${COMMAND} -c
   [ "$status" -ne 0 ]
 }
+
+@test "--set-namespace changes the namespace of the current context" {
+  use_config config1
+  switch_context user1@cluster1
+
+  _MOCK_NAMESPACES=1 run ${COMMAND} --set-namespace ns1
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = *'Active namespace is "ns1"'* ]]
+
+  run ${COMMAND} -c
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = "user1@cluster1" ]]
+}
+
+@test "--isolate writes the context to its own kubeconfig" {
+  use_config config2
+
+  run ${COMMAND} user2@cluster1 --isolate
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = *'Switched to context "user2@cluster1"'* ]]
+  [[ "$output" = *"export KUBECONFIG='${HOME}/.kube/kubectx-isolated/user2@cluster1.yaml'"* ]]
+
+  KUBECONFIG="${HOME}/.kube/kubectx-isolated/user2@cluster1.yaml" run ${COMMAND}
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = "user2@cluster1" ]]
+}
+
+@test "--undo reverts a rename" {
+  use_config config2
+
+  run ${COMMAND} new=user1@cluster1
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} --undo
+  echo "$output"
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND}
+  [[ "$output" = *"user1@cluster1"* ]]
+  [[ "$output" != *"new"* ]]
+
+  run ${COMMAND} --undo
+  [ "$status" -eq 1 ]
+  [[ "$output" = *"nothing to undo"* ]]
+}
+
+@test "--undo restores a deleted context" {
+  use_config config2
+
+  run ${COMMAND} -d user1@cluster1
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} --undo
+  echo "$output"
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND}
+  [[ "$output" = *"user1@cluster1"* ]]
+}
+
+@test "delete contexts read from stdin" {
+  use_config config2
+
+  run bash -c "printf 'user1@cluster1\n\nuser2@cluster1\n' | ${COMMAND} -d --from-stdin"
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = *"Deleted 2 contexts."* ]]
+
+  run ${COMMAND}
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = "" ]]
+}
+
+@test "locked context is not deleted without --force" {
+  use_config config2
+
+  run ${COMMAND} --lock user1@cluster1
+  echo "$output"
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} --locks
+  echo "$output"
+  [[ "$output" = "user1@cluster1" ]]
+
+  run ${COMMAND} -d user1@cluster1
+  echo "$output"
+  [ "$status" -eq 1 ]
+  [[ "$output" = *"context is locked"* ]]
+
+  run ${COMMAND} -d user1@cluster1 --force
+  echo "$output"
+  [ "$status" -eq 0 ]
+  run ${COMMAND}
+  [[ "$output" = "user2@cluster1" ]]
+}
+
+@test "--complete prints the contexts starting with a prefix" {
+  use_config config2
+
+  run ${COMMAND} --complete user2
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = "user2@cluster1" ]]
+}
+
+@test "--describe shows the note of a context" {
+  use_config config2
+
+  run ${COMMAND} --note user1@cluster1 "be careful"
+  echo "$output"
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} --describe user1@cluster1
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = *"Note:      be careful"* ]]
+
+  run ${COMMAND} --note user1@cluster1
+  run ${COMMAND} --describe user1@cluster1
+  echo "$output"
+  [[ "$output" != *"Note:"* ]]
+}
+
+@test "switch to previous context after renaming it" {
+  use_config config2
+  switch_context user1@cluster1
+  run ${COMMAND} user2@cluster1
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} renamed=user1@cluster1
+  echo "$output"
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} -
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$(get_context)" = "renamed" ]]
+}
+
+@test "--auto-single switches to the only context" {
+  use_config config1
+
+  run ${COMMAND} --auto-single
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$(get_context)" = "user1@cluster1" ]]
+}
+
+@test "KUBECTX_AUTO_SINGLE lists multiple contexts" {
+  use_config config2
+
+  KUBECTX_AUTO_SINGLE=1 run ${COMMAND}
+  echo "$output"
+  [ "$status" -eq 0 ]
+  [[ "$output" = *"user1@cluster1"*"user2@cluster1"* ]]
+}
+
+@test "--reset removes the previous context" {
+  use_config config2
+  switch_context user1@cluster1
+  run ${COMMAND} user2@cluster1
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} --reset --yes
+  echo "$output"
+  [ "$status" -eq 0 ]
+
+  run ${COMMAND} -
+  echo "$output"
+  [ "$status" -eq 1 ]
+  [[ $output = *"no previous context found" ]]
+  [[ "$(get_context)" = "user2@cluster1" ]]
+}
+
+@test "switch to a mistyped context suggests the closest names" {
+  use_config config2
+
+  run ${COMMAND} user1@clustr1
+  echo "$output"
+  [ "$status" -eq 1 ]
+  [[ "$output" = *'did you mean "user1@cluster1"'* ]]
+}
@@ -146,3 +146,76 @@ load common
   echo "$output"
   [[ "$status" -eq 1 ]]
 }
+
+@test "-c --context prints the namespace of another context" {
+  use_config config2
+  switch_context user1@cluster1
+  run ${COMMAND} ns1
+  [[ "$status" -eq 0 ]]
+  switch_context user2@cluster1
+
+  run ${COMMAND} -c --context user1@cluster1
+  echo "$output"
+  [[ "$status" -eq 0 ]]
+  [[ "$output" = "ns1" ]]
+
+  run ${COMMAND} -c
+  echo "$output"
+  [[ "$status" -eq 0 ]]
+  [[ "$output" = "default" ]]
+}
+
+@test "--context switches the namespace of another context" {
+  use_config config2
+  switch_context user2@cluster1
+
+  run ${COMMAND} --context user1@cluster1 ns1
+  echo "$output"
+  [[ "$status" -eq 0 ]]
+  [[ "$(get_context)" = "user2@cluster1" ]]
+
+  run ${COMMAND} -c --context user1@cluster1
+  echo "$output"
+  [[ "$output" = "ns1" ]]
+}
+
+@test "--context fails for a non-existing context" {
+  use_config config2
+  switch_context user2@cluster1
+
+  run ${COMMAND} --context nope ns1
+  echo "$output"
+  [[ "$status" -eq 1 ]]
+  [[ "$output" = *"no context exists with the name"* ]]
+}
+
+@test "--describe-namespace prints resource counts for the preview" {
+  use_config config1
+  switch_context user1@cluster1
+
+  run ${COMMAND} --describe-namespace ns1
+  echo "$output"
+  [[ "$status" -eq 0 ]]
+  [[ "$output" = *"Pods:        3"* ]]
+  [[ "$output" = *"Deployments: 1"* ]]
+}
+
+@test "--complete prints the namespaces starting with a prefix" {
+  use_config config1
+  switch_context user1@cluster1
+
+  run ${COMMAND} --complete ns2
+  echo "$output"
+  [[ "$status" -eq 0 ]]
+  [[ "$output" = "ns2" ]]
+}
+
+@test "switch to a mistyped namespace suggests the closest names" {
+  use_config config1
+  switch_context user1@cluster1
+
+  run ${COMMAND} nss1
+  echo "$output"
+  [[ "$status" -eq 1 ]]
+  [[ "$output" = *'did you mean "ns1"'* ]]
+}